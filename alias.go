@@ -1,39 +1,64 @@
 package di
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 )
 
-func alias(s *Scope, r reflect.Type, of reflect.Type, provider reflect.Type) error {
-	if !of.ConvertibleTo(r) {
-		return newErrNotConvertible(of, r)
+func alias(s *Scope, b binding, of key, provider reflect.Type) error {
+	r := b.t
+
+	if !of.t.ConvertibleTo(r) {
+		return newErrNotConvertible(of.t, r)
 	}
 
-	s.registerProvider(r, reflect.MakeFunc(
-		provider,
-		func(args []reflect.Value) []reflect.Value {
-			resolver := args[0].Interface().(*Scope)
-			trace := args[1].Interface().(trace)
+	_, err := s.registerProvider(&node{
+		binding: b,
+		kind:    "Alias",
+		deps:    []dependency{{key: of}},
+		provider: reflect.MakeFunc(
+			provider,
+			func(args []reflect.Value) []reflect.Value {
+				ctx := args[0].Interface().(context.Context)
+				resolver := args[1].Interface().(*Scope)
+				trace := args[2].Interface().(trace)
 
-			result := []reflect.Value{reflect.Zero(r), reflect.Zero(reflect.TypeFor[error]())}
+				result := []reflect.Value{reflect.Zero(r), reflect.Zero(reflect.TypeFor[error]())}
 
-			if out, err := resolver.resolve(of, trace); err != nil {
-				result[1] = reflect.ValueOf(err)
-			} else {
-				result[0] = out.Convert(r)
-			}
+				if out, err := resolver.resolve(ctx, of, trace); err != nil {
+					result[1] = reflect.ValueOf(err)
+				} else {
+					result[0] = out.Convert(r)
+				}
 
-			return result
-		},
-	))
+				return result
+			},
+		),
+	})
 
-	return nil
+	return err
 }
 
 // AliasBuilder provides configuration of an [Alias].
 type AliasBuilder interface {
 	Registrable
+	// Source returns the location at which this alias was defined.
+	Source() Source
+	// Named configures the name under which this alias is registered.
+	// See [ResolveNamedIn] for details.
+	Named(name string) AliasBuilder
+	// Group configures this alias as a member of the group for its resolved type R,
+	// rather than as the sole registration of R. Resolving []R returns the values of
+	// all members of the group, in registration order.
+	Group() AliasBuilder
+	// MapKey configures this alias as the entry with the given key in the map for its
+	// resolved type R, rather than as the sole registration of R. Resolving map[string]R
+	// returns the values of all entries of the map, with later registrations of a key
+	// taking precedence.
+	MapKey(key string) AliasBuilder
+	// OfNamed configures the name of the aliased registration.
+	OfNamed(name string) AliasBuilder
 }
 
 // Alias defines a pass-through from one resolved type to another.
@@ -44,20 +69,42 @@ type AliasBuilder interface {
 //   - The aliased type is resolved from the scope in which the alias is being resolved.
 func Alias[R, Of any]() AliasBuilder {
 	return &aliasBuilder{
-		r:        reflect.TypeFor[R](),
-		of:       reflect.TypeFor[Of](),
+		binding:  binding{key: key{t: reflect.TypeFor[R]()}, source: callerSource()},
+		of:       key{t: reflect.TypeFor[Of]()},
 		provider: reflect.TypeFor[provider[R]](),
 	}
 }
 
 type aliasBuilder struct {
-	r, of, provider reflect.Type
+	binding
+	of       key
+	provider reflect.Type
 }
 
 func (b *aliasBuilder) String() string {
-	return fmt.Sprintf("Alias[%s, %s]", typeName(b.r), typeName(b.of))
+	return fmt.Sprintf("Alias[%v, %v] at %v", b.binding, b.of, b.source)
+}
+
+func (b *aliasBuilder) Named(name string) AliasBuilder {
+	b.name = name
+	return b
+}
+
+func (b *aliasBuilder) Group() AliasBuilder {
+	b.group, b.mapped = true, false
+	return b
+}
+
+func (b *aliasBuilder) MapKey(key string) AliasBuilder {
+	b.group, b.mapped, b.mapKey = false, true, key
+	return b
+}
+
+func (b *aliasBuilder) OfNamed(name string) AliasBuilder {
+	b.of.name = name
+	return b
 }
 
 func (b *aliasBuilder) register(s *Scope) error {
-	return alias(s, b.r, b.of, b.provider)
+	return alias(s, b.binding, b.of, b.provider)
 }
@@ -60,4 +60,31 @@ func TestAlias(t *testing.T) {
 
 		s.MustDestroy()
 	})
+
+	t.Run("Named", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[float64](1.2),
+			di.Instance[float64](3.4).Named("three"),
+			di.Alias[int, float64]().Named("one"),
+			di.Alias[int, float64]().OfNamed("three"))
+
+		assert.Equal(t, 1, di.MustResolveNamedIn[int](s, "one"))
+		assert.Equal(t, 3, di.MustResolveIn[int](s))
+
+		s.MustDestroy()
+	})
+
+	t.Run("NamedNotRegistered", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[float64](1.2),
+			di.Alias[int, float64]().OfNamed("three"))
+
+		_, err := di.ResolveIn[int](s)
+		assert.ErrorIs(t, err, di.ErrNotRegistered)
+		assert.ErrorContains(t, err, `float64 "three"`)
+
+		s.MustDestroy()
+	})
 }
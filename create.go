@@ -28,9 +28,29 @@ func validateCreate(r reflect.Type, create reflect.Value) (reflect.Type, error)
 		return nil, newErrNotConvertible(v, r)
 	}
 
+	if _, err := params(c, 0, nil); err != nil {
+		return nil, err
+	}
+
 	return v, nil
 }
 
+func validateNames(name string, f reflect.Value, given int, names []string) error {
+	t := f.Type()
+
+	if t.NumIn()-given < len(names) {
+		return newErrInvalidNames(name, f, names)
+	}
+
+	for i, n := range names {
+		if n != "" && isIn(t.In(given+i)) {
+			return newErrInvalidNames(name, f, names)
+		}
+	}
+
+	return nil
+}
+
 // IsValidCreate checks whether a create function is valid for the given resolved type R.
 // This check is performed internally when registering create functions (e.g., with [Factory]),
 // thus this method does not typically need to be called explicitly.
@@ -39,7 +59,7 @@ func validateCreate(r reflect.Type, create reflect.Value) (reflect.Type, error)
 //   - a non-nil function returning (T) or (T, error), where T is convertible to R
 //
 // There are no restrictions on its input parameters. They will be resolved as dependencies
-// when create is called to produce a value.
+// when create is called to produce a value. Any parameter structs must be valid (see [In]).
 func IsValidCreate[R any](create any) bool {
 	_, err := validateCreate(reflect.TypeFor[R](), reflect.ValueOf(create))
 	return err == nil
@@ -0,0 +1,225 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime"
+)
+
+func validateDecorate(r reflect.Type, decorate reflect.Value) error {
+	if !decorate.IsValid() {
+		return newErrNil("decorate")
+	}
+	if decorate.Kind() != reflect.Func {
+		return newErrNotFunc("decorate", decorate)
+	}
+	if decorate.IsNil() {
+		return newErrNil("decorate")
+	}
+
+	d := decorate.Type()
+
+	if d.NumIn() < 1 ||
+		(d.NumOut() != 1 &&
+			(d.NumOut() != 2 || d.Out(1) != reflect.TypeFor[error]())) {
+		return newErrInvalidFunc("decorate", decorate)
+	}
+
+	if v0 := d.In(0); !r.AssignableTo(v0) {
+		return newErrNotAssignable(r, v0)
+	}
+
+	if v := d.Out(0); !v.ConvertibleTo(r) {
+		return newErrNotConvertible(v, r)
+	}
+
+	if _, err := params(d, 1, nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// IsValidDecorate checks whether a decorate function is valid for the given resolved type R.
+// This check is performed internally when registering decorate functions (e.g., with [Decorate]),
+// thus this method does not typically need to be called explicitly.
+//
+// In order for decorate to be valid for resolved type R, it must be:
+//   - a non-nil function of the form func(T, ...) (U) or func(T, ...) (U, error),
+//     where R is assignable to T, and U is convertible to R
+//
+// There are no restrictions on its input parameters following the first. They will be resolved
+// as dependencies when decorate is called to wrap a value. Any parameter structs must be valid (see [In]).
+func IsValidDecorate[R any](decorate any) bool {
+	err := validateDecorate(reflect.TypeFor[R](), reflect.ValueOf(decorate))
+	return err == nil
+}
+
+func decorator(s *Scope, d DecorateBuilder, k key, decorate reflect.Value, names []string) error {
+	r := k.t
+
+	if err := validateDecorate(r, decorate); err != nil {
+		return err
+	}
+
+	if err := validateNames("decorate", decorate, 1, names); err != nil {
+		return err
+	}
+
+	return s.registerDecorator(k, func(inner *node) *node {
+		apply := func(args []reflect.Value) []reflect.Value {
+			ctx := args[0].Interface().(context.Context)
+			resolver := args[1].Interface().(*Scope)
+			trace := args[2].Interface().(trace)
+
+			result := []reflect.Value{reflect.Zero(r), reflect.Zero(reflect.TypeFor[error]())}
+
+			value := inner.provider.Call(args)
+			if err, _ := value[1].Interface().(error); err != nil {
+				result[1] = reflect.ValueOf(newErrDecorate(resolver, d, err))
+				return result
+			}
+
+			if out, err := resolver.invokeWith(ctx, decorate, value[:1], names, trace); err != nil {
+				result[1] = reflect.ValueOf(newErrDecorate(resolver, d, err))
+			} else if 1 < len(out) && !out[1].IsNil() {
+				result[1] = reflect.ValueOf(newErrDecorate(resolver, d, out[1].Interface().(error)))
+			} else {
+				result[0] = out[0].Convert(r)
+			}
+
+			return result
+		}
+
+		n := &node{
+			binding:   inner.binding,
+			kind:      "Decorate",
+			deps:      dependencies(decorate.Type(), 1, names),
+			decorated: inner,
+			provider:  reflect.MakeFunc(inner.provider.Type(), apply),
+		}
+
+		base := inner
+		for base.decorated != nil {
+			base = base.decorated
+		}
+
+		// the decorated value is cached for the same lifetime as the value it wraps
+		switch base.kind {
+		case "Singleton", "Instance", "Provide":
+			n.home = s
+			c := new(cached)
+			n.provider = reflect.MakeFunc(
+				inner.provider.Type(),
+				func(args []reflect.Value) []reflect.Value {
+					return c.decorate(apply, []reflect.Value{args[0], reflect.ValueOf(s), args[2]})
+				},
+			)
+		case "Scoped":
+			id := new(byte)
+			n.provider = reflect.MakeFunc(
+				inner.provider.Type(),
+				func(args []reflect.Value) []reflect.Value {
+					resolver := args[1].Interface().(*Scope)
+					return resolver.cached(id).decorate(apply, args)
+				},
+			)
+		}
+
+		return n
+	})
+}
+
+// decorate returns the cached result, or else the result of apply,
+// which is cached only if it succeeds.
+func (c *cached) decorate(apply func([]reflect.Value) []reflect.Value, args []reflect.Value) []reflect.Value {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.done {
+		return c.result
+	}
+
+	result := apply(args)
+	if result[1].IsNil() {
+		c.result, c.done = result, true
+	}
+
+	return result
+}
+
+// DecorateBuilder provides configuration of a [Decorate].
+type DecorateBuilder interface {
+	Registrable
+	// Source returns the location at which this decorate was defined.
+	Source() Source
+	// Named configures the name of the registration decorated by this decorate.
+	// See [ResolveNamedIn] for details.
+	Named(name string) DecorateBuilder
+	// ArgNames configures the names of the registrations resolved for the
+	// parameters of the decorate function following the decorated value, in order.
+	// An empty name resolves the unnamed registration, as do any parameters
+	// beyond the given names. Parameter structs (see [In]) are configured by their tags,
+	// and must be given an empty name.
+	ArgNames(names ...string) DecorateBuilder
+}
+
+// Decorate defines a wrapper for the values of an existing registration.
+// The type parameter R defines the resolved type of the registration to be decorated,
+// which must already be registered at the time of decoration.
+// See [IsValidDecorate] for details.
+//
+// Decorate passes the decorated value to its decorate function, and returns the result
+// in place of the decorated value. The result is cached for the same lifetime as the decorated value:
+//   - Once for a [Singleton], [Instance] or [Provide], with dependencies resolved
+//     from the scope in which the decorate was registered.
+//   - Once per resolving scope for a [Scoped], with dependencies resolved from that scope.
+//   - Never for any other registration (e.g., [Factory]), with dependencies resolved
+//     from the scope in which the decorate is being resolved.
+//
+// Dependencies are resolved at the time of decoration, and a failed decoration is not cached.
+// Multiple decorates for the same registration are applied in registration order.
+func Decorate[R any](decorate any) DecorateBuilder {
+	return &decorateBuilder{
+		r:        reflect.TypeFor[R](),
+		decorate: reflect.ValueOf(decorate),
+		source:   callerSource(),
+	}
+}
+
+type decorateBuilder struct {
+	r        reflect.Type
+	name     string
+	names    []string
+	decorate reflect.Value
+	source   Source
+}
+
+func (b *decorateBuilder) String() string {
+	s := fmt.Sprintf("Decorate[%v]", key{b.r, b.name})
+	if b.decorate.Kind() == reflect.Func && !b.decorate.IsNil() {
+		if f := runtime.FuncForPC(b.decorate.Pointer()); f != nil {
+			s += " " + f.Name()
+		}
+	}
+	return fmt.Sprintf("%s at %v", s, b.source)
+}
+
+func (b *decorateBuilder) Source() Source {
+	return b.source
+}
+
+func (b *decorateBuilder) Named(name string) DecorateBuilder {
+	b.name = name
+	return b
+}
+
+func (b *decorateBuilder) ArgNames(names ...string) DecorateBuilder {
+	b.names = names
+	return b
+}
+
+func (b *decorateBuilder) register(s *Scope) error {
+	return decorator(s, b, key{b.r, b.name}, b.decorate, b.names)
+}
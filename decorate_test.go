@@ -0,0 +1,346 @@
+package di_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/michaeljpetter/di"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestIsValidDecorate(t *testing.T) {
+	type interfaceT interface{ M() }
+	type structT struct{ v int }
+	type intT int
+
+	noCall := func() { t.Error("should not call decorate function") }
+
+	t.Run("NoArgsReturns1", func(t *testing.T) {
+		assert.True(t, di.IsValidDecorate[*int](
+			func(*int) *int { noCall(); return nil },
+		))
+	})
+
+	t.Run("NoArgsReturns2", func(t *testing.T) {
+		assert.True(t, di.IsValidDecorate[*int](
+			func(*int) (*int, error) { noCall(); return nil, nil },
+		))
+	})
+
+	t.Run("ArgsReturns1", func(t *testing.T) {
+		assert.True(t, di.IsValidDecorate[interfaceT](
+			func(interfaceT, string, *int) interfaceT { noCall(); return nil },
+		))
+	})
+
+	t.Run("ArgsReturns2", func(t *testing.T) {
+		assert.True(t, di.IsValidDecorate[interfaceT](
+			func(interfaceT, []string, any) (interfaceT, error) { noCall(); return nil, nil },
+		))
+	})
+
+	t.Run("NoValue", func(t *testing.T) {
+		assert.False(t, di.IsValidDecorate[*int](
+			func() *int { noCall(); return nil },
+		))
+	})
+
+	t.Run("NoReturns", func(t *testing.T) {
+		assert.False(t, di.IsValidDecorate[*int](
+			func(*int) { noCall() },
+		))
+	})
+
+	t.Run("TooManyReturns", func(t *testing.T) {
+		assert.False(t, di.IsValidDecorate[*int](
+			func(*int) (*int, error, string) { noCall(); return nil, nil, "" },
+		))
+	})
+
+	t.Run("SecondReturnNotError", func(t *testing.T) {
+		assert.False(t, di.IsValidDecorate[*int](
+			func(*int) (*int, string) { noCall(); return nil, "" },
+		))
+	})
+
+	t.Run("ValueAssignable", func(t *testing.T) {
+		assert.True(t, di.IsValidDecorate[interfaceT](
+			func(any) interfaceT { noCall(); return nil },
+		))
+	})
+
+	t.Run("ValueNotAssignable", func(t *testing.T) {
+		assert.False(t, di.IsValidDecorate[intT](
+			func(int) intT { noCall(); return 0 },
+		))
+	})
+
+	t.Run("ReturnsConvertible", func(t *testing.T) {
+		assert.True(t, di.IsValidDecorate[intT](
+			func(intT) int64 { noCall(); return 0 },
+		))
+	})
+
+	t.Run("ReturnsNotConvertible", func(t *testing.T) {
+		assert.False(t, di.IsValidDecorate[interfaceT](
+			func(interfaceT) *structT { noCall(); return nil },
+		))
+	})
+
+	t.Run("NilFunc", func(t *testing.T) {
+		assert.False(t, di.IsValidDecorate[interfaceT](
+			(func(interfaceT) interfaceT)(nil),
+		))
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		assert.False(t, di.IsValidDecorate[*structT](nil))
+	})
+
+	t.Run("NotFunc", func(t *testing.T) {
+		assert.False(t, di.IsValidDecorate[int](123))
+	})
+}
+
+func TestDecorate(t *testing.T) {
+	t.Run("Minimal", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[int](rotate(3, 5)),
+			di.Decorate[int](func(i int) int { return 10 * i }))
+
+		assert.Equal(t, 30, di.MustResolveIn[int](s))
+		assert.Equal(t, 50, di.MustResolveIn[int](s))
+
+		s.MustDestroy()
+	})
+
+	t.Run("Stacked", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[string]("a"),
+			di.Decorate[string](func(v string) string { return v + "b" }),
+			di.Decorate[string](func(v string) string { return v + "c" }))
+
+		assert.Equal(t, "abc", di.MustResolveIn[string](s))
+
+		s.MustDestroy()
+	})
+
+	t.Run("Dependent", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](7),
+			di.Instance[int](11).Named("eleven"),
+			di.Factory[string](func() string { return "a" }),
+			di.Decorate[string](func(v string, i, j int) string { return fmt.Sprint(v, i, j) }).
+				ArgNames("eleven"))
+
+		c := s.NewChild("child")
+		c.MustRegister(
+			di.Instance[int](13))
+
+		assert.Equal(t, "a11 7", di.MustResolveIn[string](s))
+		assert.Equal(t, "a11 13", di.MustResolveIn[string](c))
+
+		s.MustDestroy()
+	})
+
+	t.Run("Singleton", func(t *testing.T) {
+		type svc struct{ inner *int }
+
+		calls := 0
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Singleton[*int](func() *int { return new(int) }),
+			di.Decorate[*int](func(v *int) *int { calls++; return v }),
+			di.Singleton[*svc](func() *svc { return &svc{} }),
+			di.Decorate[*svc](func(v *svc, i *int) *svc { calls++; return &svc{i} }))
+
+		c := s.NewChild("child")
+
+		a := di.MustResolveIn[*svc](s)
+		b := di.MustResolveIn[*svc](c)
+		assert.Same(t, a, b)
+		assert.Same(t, di.MustResolveIn[*int](c), a.inner)
+		assert.Equal(t, 2, calls)
+
+		s.MustDestroy()
+	})
+
+	t.Run("Scoped", func(t *testing.T) {
+		calls := 0
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Scoped[*int](func() *int { return new(int) }),
+			di.Decorate[*int](func(v *int) *int { calls++; return v }))
+
+		c := s.NewChild("child")
+
+		assert.Same(t, di.MustResolveIn[*int](s), di.MustResolveIn[*int](s))
+		assert.Same(t, di.MustResolveIn[*int](c), di.MustResolveIn[*int](c))
+		assert.NotSame(t, di.MustResolveIn[*int](s), di.MustResolveIn[*int](c))
+		assert.Equal(t, 2, calls)
+
+		s.MustDestroy()
+	})
+
+	t.Run("ErrorNotCached", func(t *testing.T) {
+		fail := true
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[string]("a"),
+			di.Decorate[string](func(v string) (string, error) {
+				if fail {
+					return "", errors.New("whoops")
+				}
+				return v + "!", nil
+			}))
+
+		_, err := di.ResolveIn[string](s)
+		assert.ErrorContains(t, err, "whoops")
+
+		fail = false
+		assert.Equal(t, "a!", di.MustResolveIn[string](s))
+
+		s.MustDestroy()
+	})
+
+	t.Run("Named", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[string]("a"),
+			di.Instance[string]("b").Named("b"),
+			di.Decorate[string](func(v string) string { return v + "!" }).Named("b"))
+
+		assert.Equal(t, "a", di.MustResolveIn[string](s))
+		assert.Equal(t, "b!", di.MustResolveNamedIn[string](s, "b"))
+
+		s.MustDestroy()
+	})
+
+	t.Run("Child", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[string]("a"))
+
+		c := s.NewChild("child")
+		c.MustRegister(
+			di.Decorate[string](func(v string) string { return v + "!" }))
+
+		assert.Equal(t, "a", di.MustResolveIn[string](s))
+		assert.Equal(t, "a!", di.MustResolveIn[string](c))
+
+		s.MustDestroy()
+	})
+
+	t.Run("Group", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1).Group(),
+			di.Instance[int](2).Group(),
+			di.Decorate[[]int](func(v []int) []int { return append(v, 3) }))
+
+		assert.Equal(t, []int{1, 2, 3}, di.MustResolveIn[[]int](s))
+
+		s.MustDestroy()
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[string]("a"),
+			di.Decorate[string](func(v string) string { return v }),
+			di.Decorate[string](func(v string) (string, error) { return v, errors.New("whoops") }))
+
+		value, err := di.ResolveIn[string](s)
+		assert.Zero(t, value)
+		assert.ErrorIs(t, err, di.ErrResolve)
+		assert.ErrorIs(t, err, di.ErrDecorate)
+		assert.ErrorContains(t, err, "decorate: test <- Decorate[string]")
+		assert.ErrorContains(t, err, "whoops")
+
+		s.MustDestroy()
+	})
+
+	t.Run("InnerError", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[string](func() (string, error) { return "", errors.New("whoops") }),
+			di.Decorate[string](func(v string) string { return v }),
+			di.Decorate[string](func(v string) string { return v }))
+
+		_, err := di.ResolveIn[string](s)
+		assert.ErrorIs(t, err, di.ErrDecorate)
+		assert.ErrorContains(t, err, "whoops")
+		assert.Equal(t, 2, strings.Count(err.Error(), "decorate: test <- Decorate[string]"))
+
+		s.MustDestroy()
+	})
+
+	t.Run("NotRegistered", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[string]("a"),
+			di.Decorate[string](func(v string, i int) string { return v }))
+
+		_, err := di.ResolveIn[string](s)
+		assert.ErrorIs(t, err, di.ErrDecorate)
+		assert.ErrorIs(t, err, di.ErrNotRegistered)
+
+		s.MustDestroy()
+	})
+
+	t.Run("DecoratedNotRegistered", func(t *testing.T) {
+		s := di.NewScope("test").NewChild("child")
+
+		err := s.Register(
+			di.Decorate[string](func(v string) string { return v }))
+
+		assert.ErrorIs(t, err, di.ErrRegister)
+		assert.ErrorIs(t, err, di.ErrNotRegistered)
+		assert.ErrorContains(t, err, "string (searched child, test)")
+
+		s.MustDestroy()
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1),
+			di.Decorate[int](func(v, _ int) int { return v }))
+
+		_, err := di.ResolveIn[int](s)
+		assert.ErrorIs(t, err, di.ErrCycle)
+
+		s.MustDestroy()
+	})
+
+	t.Run("InvalidDecorate", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1))
+
+		err := s.Register(
+			di.Decorate[int](func() int { return 0 }))
+
+		assert.ErrorIs(t, err, di.ErrInvalidFunc)
+
+		s.MustDestroy()
+	})
+
+	t.Run("InvalidArgNames", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1))
+
+		err := s.Register(
+			di.Decorate[int](func(v int) int { return v }).
+				ArgNames("a"))
+
+		assert.ErrorIs(t, err, di.ErrInvalidNames)
+
+		s.MustDestroy()
+	})
+}
@@ -1,36 +1,12 @@
 package di
 
 import (
-	"fmt"
+	"context"
 	"reflect"
 )
 
 func validateDestroy(v reflect.Type, destroy reflect.Value) error {
-	if !destroy.IsValid() {
-		return nil
-	}
-	if destroy.Kind() != reflect.Func {
-		return newErrNotFunc("destroy", destroy)
-	}
-	if destroy.IsNil() {
-		return nil
-	}
-
-	d := destroy.Type()
-
-	if d.NumIn() != 1 ||
-		(d.NumOut() != 0 &&
-			(d.NumOut() != 1 || d.Out(0) != reflect.TypeFor[error]())) {
-		return newErrInvalidFunc("destroy", destroy)
-	}
-
-	v0 := d.In(0)
-
-	if !v.AssignableTo(v0) {
-		return newErrNotAssignable(v, v0)
-	}
-
-	return nil
+	return validateHook("destroy", v, destroy)
 }
 
 // IsValidDestroy checks whether a destroy function is valid for the given value type V.
@@ -41,6 +17,8 @@ func validateDestroy(v reflect.Type, destroy reflect.Value) error {
 //   - an untyped nil
 //   - a nil function
 //   - a non-nil function of the form func(T) or func(T) error, where V is assignable to T
+//   - a non-nil function of the form func(context.Context, T) or func(context.Context, T) error,
+//     where V is assignable to T (see [Scope.DestroyContext])
 func IsValidDestroy[V any](destroy any) bool {
 	err := validateDestroy(reflect.TypeFor[V](), reflect.ValueOf(destroy))
 	return err == nil
@@ -48,17 +26,54 @@ func IsValidDestroy[V any](destroy any) bool {
 
 type destroyer struct {
 	value, destroy reflect.Value
+	source         Source
 }
 
-func (d destroyer) String() string {
-	return fmt.Sprintf("[%s] %v", typeName(valueType(d.value)), d.value.Interface())
-}
+func (d destroyer) destroyIn(ctx context.Context, s *Scope) error {
+	if 0 < s.destroyTimeout {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.destroyTimeout)
+		defer cancel()
+	}
+
+	args := hookArgs(ctx, d.destroy, d.value)
+
+	if ctx.Done() == nil {
+		return s.callHook(d.destroy, args)
+	}
+
+	// the destroy function is abandoned if it outlasts the context,
+	// and any panic is raised again in the destroying goroutine
+	type result struct {
+		err       error
+		panicked  bool
+		recovered any
+	}
+	done := make(chan result, 1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- result{panicked: true, recovered: r}
+			}
+		}()
+		done <- result{err: s.callHook(d.destroy, args)}
+	}()
+
+	var r result
+	select {
+	case r = <-done:
+	case <-ctx.Done():
+		// a destroy function which finished along with its context is not abandoned
+		select {
+		case r = <-done:
+		default:
+			return ctx.Err()
+		}
+	}
 
-func (d destroyer) Destroy() error {
-	out := d.destroy.Call([]reflect.Value{d.value})
-	var err error
-	if 0 < len(out) {
-		err, _ = out[0].Interface().(error)
+	if r.panicked {
+		panic(r.recovered)
 	}
-	return err
+	return r.err
 }
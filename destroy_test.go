@@ -1,6 +1,7 @@
 package di_test
 
 import (
+	"context"
 	"github.com/michaeljpetter/di"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -25,6 +26,30 @@ func TestIsValidDestroy(t *testing.T) {
 		))
 	})
 
+	t.Run("ContextArgNoReturns", func(t *testing.T) {
+		assert.True(t, di.IsValidDestroy[*int](
+			func(context.Context, *int) { noCall() },
+		))
+	})
+
+	t.Run("ContextArgReturnsError", func(t *testing.T) {
+		assert.True(t, di.IsValidDestroy[*structT](
+			func(context.Context, *structT) error { noCall(); return nil },
+		))
+	})
+
+	t.Run("ContextNotFirst", func(t *testing.T) {
+		assert.False(t, di.IsValidDestroy[*structT](
+			func(*structT, context.Context) { noCall() },
+		))
+	})
+
+	t.Run("ContextOnly", func(t *testing.T) {
+		assert.False(t, di.IsValidDestroy[*structT](
+			func(context.Context) { noCall() },
+		))
+	})
+
 	t.Run("NoArgs", func(t *testing.T) {
 		assert.False(t, di.IsValidDestroy[*int](
 			func() { noCall() },
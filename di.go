@@ -17,31 +17,119 @@
 // resolved:
 //
 //	my, err := di.ResolveIn[MyInterface](root)
+//
+// Scopes may be nested with [Scope.NewChild], such as for a single request or job.
+// A child scope resolves from its own registrations first, and falls back to its parent:
+//
+//	request := root.NewChild("request")
+//	defer request.Destroy()
+//
+// Several registrations may contribute to a group of the same type,
+// which is resolved as a slice of all of their values:
+//
+//	root.Register(
+//		Factory[Route](users.NewRoute).Group(),
+//		Factory[Route](orders.NewRoute).Group())
+//
+//	routes, err := di.ResolveIn[[]Route](root)
+//
+// Likewise, registrations may contribute keyed entries to a map of the same type,
+// which is resolved as a map[string] of all of their values:
+//
+//	root.Register(
+//		Singleton[Codec](json.NewCodec).MapKey("application/json"),
+//		Singleton[Codec](xml.NewCodec).MapKey("application/xml"))
+//
+//	codecs, err := di.ResolveIn[map[string]Codec](root)
 package di
 
 import (
+	"context"
+	"fmt"
 	"reflect"
 	"strings"
 )
 
-type trace []reflect.Type
+type key struct {
+	t    reflect.Type
+	name string
+}
+
+func (k key) String() string {
+	if k.name == "" {
+		return typeName(k.t)
+	}
+	return fmt.Sprintf("%s %q", typeName(k.t), k.name)
+}
+
+type binding struct {
+	key
+	group  bool
+	mapped bool
+	mapKey string
+	source Source
+}
+
+// Source returns the location at which the registration was defined.
+func (b binding) Source() Source {
+	return b.source
+}
+
+func (b binding) String() string {
+	switch {
+	case b.group:
+		return fmt.Sprintf("%v (group)", b.key)
+	case b.mapped:
+		return fmt.Sprintf("%v (key %q)", b.key, b.mapKey)
+	default:
+		return b.key.String()
+	}
+}
+
+// node is a registered provider, along with the metadata describing it.
+type node struct {
+	binding
+	kind     string
+	provider reflect.Value
+	deps     []dependency
+
+	// home is the scope from which deps are resolved, or nil for the resolving scope.
+	home *Scope
+	// owner is the scope in which a collection of members was registered, if any.
+	owner *Scope
+	// decorated is the node wrapped by a decorator, if any.
+	decorated *node
+}
+
+type trace []key
 
 func (t trace) String() string {
 	names := make([]string, len(t))
-	for i, t := range t {
-		names[i] = typeName(t)
+	for i, k := range t {
+		names[i] = k.String()
 	}
 	return strings.Join(names, " -> ")
 }
 
-type provider[R any] func(*Scope, trace) (R, error)
+type provider[R any] func(context.Context, *Scope, trace) (R, error)
+
+func providerOf(r reflect.Type) reflect.Type {
+	return reflect.FuncOf(
+		[]reflect.Type{reflect.TypeFor[context.Context](), reflect.TypeFor[*Scope](), reflect.TypeFor[trace]()},
+		[]reflect.Type{r, reflect.TypeFor[error]()},
+		false)
+}
 
 // Registrable is the base interface implemented by all
 // registration builders in the di package. The following builders are available:
 //   - [Instance]
 //   - [Factory]
 //   - [Singleton]
+//   - [Scoped]
+//   - [Provide]
 //   - [Alias]
+//   - [Decorate]
+//   - [Module]
 type Registrable interface {
 	register(*Scope) error
 }
@@ -4,6 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"runtime/debug"
+	"slices"
+	"strings"
 )
 
 const errSeparator = "\n └> "
@@ -29,6 +32,13 @@ func newErrNotFunc(name string, f reflect.Value) error {
 	return fmt.Errorf("%w: %s (received %s)", ErrNotFunc, name, typeName(valueType(f)))
 }
 
+// ErrNotStructPointer indicates the invalid use of an argument that is not a pointer to a struct.
+var ErrNotStructPointer = fmt.Errorf("%w: must be a pointer to a struct", Err)
+
+func newErrNotStructPointer(name string, v reflect.Value) error {
+	return fmt.Errorf("%w: %s (received %s)", ErrNotStructPointer, name, typeName(valueType(v)))
+}
+
 // ErrInvalidFunc indicates the use of a function with an invalid signature.
 var ErrInvalidFunc = fmt.Errorf("%w: invalid function", Err)
 
@@ -36,6 +46,21 @@ func newErrInvalidFunc(name string, f reflect.Value) error {
 	return fmt.Errorf("%w: %s as %s", ErrInvalidFunc, name, typeName(valueType(f)))
 }
 
+// ErrInvalidNames indicates the use of argument names that do not fit a function.
+var ErrInvalidNames = fmt.Errorf("%w: invalid names", Err)
+
+func newErrInvalidNames(name string, f reflect.Value, names []string) error {
+	return fmt.Errorf("%w: %d for %s as %s", ErrInvalidNames, len(names), name, typeName(valueType(f)))
+}
+
+// ErrInvalidField indicates the use of a struct field that is unexported, or has an invalid di tag.
+// See [In] for details.
+var ErrInvalidField = fmt.Errorf("%w: invalid field", Err)
+
+func newErrInvalidField(t reflect.Type, f reflect.StructField) error {
+	return fmt.Errorf("%w: %s.%s `%s`", ErrInvalidField, typeName(t), f.Name, f.Tag)
+}
+
 // ErrNotConvertible indicates the use of incompatible types where convertibility is required.
 var ErrNotConvertible = fmt.Errorf("%w: not convertible", Err)
 
@@ -50,44 +75,386 @@ func newErrNotAssignable(from reflect.Type, to reflect.Type) error {
 	return fmt.Errorf("%w: %s to %s", ErrNotAssignable, typeName(from), typeName(to))
 }
 
+// Key identifies a registration by its resolved type and name,
+// as reported by the structured errors of this package.
+type Key struct {
+	Type reflect.Type
+	Name string
+}
+
+func (k Key) String() string {
+	return key{k.Type, k.Name}.String()
+}
+
+func (t trace) keys() []Key {
+	keys := make([]Key, len(t))
+	for i, k := range t {
+		keys[i] = Key{k.t, k.name}
+	}
+	return keys
+}
+
+func keysString(keys []Key) string {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = k.String()
+	}
+	return strings.Join(names, " -> ")
+}
+
 // ErrNotRegistered indicates that no registration was found for a resolved type.
+// Errors of this kind are reported as [*NotRegisteredError].
 var ErrNotRegistered = fmt.Errorf("%w: not registered", Err)
 
-func newErrNotRegistered(r reflect.Type) error {
-	return fmt.Errorf("%w: %s", ErrNotRegistered, typeName(r))
+// NotRegisteredError is the [ErrNotRegistered] reported for a specific type.
+type NotRegisteredError struct {
+	// Key is the unregistered type and name.
+	Key Key
+	// Searched are the scopes searched for the registration, from nearest to root.
+	Searched []*Scope
+	// Suggestions are registrations within the searched scopes which may have been intended instead:
+	//   - the pointer or value form of the type
+	//   - an interface implemented by the type, or a type implementing the interface
+	//   - a type of the same name from a different package
+	Suggestions []Key
+}
+
+func newErrNotRegistered(k key, searched []*Scope) error {
+	return &NotRegisteredError{Key{k.t, k.name}, slices.Clone(searched), suggestions(k, searched)}
+}
+
+func (e *NotRegisteredError) Error() string {
+	names := make([]string, len(e.Searched))
+	for i, s := range e.Searched {
+		names[i] = s.String()
+	}
+	if len(e.Suggestions) == 0 {
+		return fmt.Sprintf("%v: %v (searched %s)", ErrNotRegistered, e.Key, strings.Join(names, ", "))
+	}
+
+	suggestions := make([]string, len(e.Suggestions))
+	for i, k := range e.Suggestions {
+		suggestions[i] = k.String()
+	}
+	return fmt.Sprintf("%v: %v (searched %s; did you mean %s?)",
+		ErrNotRegistered, e.Key, strings.Join(names, ", "), strings.Join(suggestions, " or "))
+}
+
+func (e *NotRegisteredError) Unwrap() error {
+	return ErrNotRegistered
+}
+
+// ErrDuplicate indicates that a registration duplicates an existing registration.
+// See [OnDuplicate].
+var ErrDuplicate = fmt.Errorf("%w: duplicate registration", Err)
+
+func newErrDuplicate(b binding) error {
+	return fmt.Errorf("%w: %v", ErrDuplicate, b)
 }
 
 // ErrCycle indicates that a cycle was detected during resolution.
+// Errors of this kind are reported as [*CycleError].
 var ErrCycle = fmt.Errorf("%w: cycle detected", Err)
 
+// CycleError is the [ErrCycle] reported for a specific cycle.
+type CycleError struct {
+	// Trace is the cycle, beginning and ending with the same key.
+	Trace []Key
+}
+
 func newErrCycle(t trace) error {
-	return fmt.Errorf("%w: %v", ErrCycle, t)
+	return &CycleError{t.keys()}
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("%v: %s", ErrCycle, keysString(e.Trace))
+}
+
+func (e *CycleError) Unwrap() error {
+	return ErrCycle
+}
+
+// ErrDeferred indicates that an error occurred during deferred resolution
+// (e.g., with [Lazy]), and wraps the error detail.
+// Errors of this kind are reported as [*DeferredError].
+var ErrDeferred = fmt.Errorf("%w: deferred", Err)
+
+// DeferredError is the [ErrDeferred] reported for a specific deferred resolution.
+type DeferredError struct {
+	// Trace is the chain of resolutions in which the deferred dependency was created.
+	Trace []Key
+	// Err is the error detail.
+	Err error
+}
+
+func newErrDeferred(t trace, err error) error {
+	return &DeferredError{t.keys(), err}
+}
+
+func (e *DeferredError) Error() string {
+	return fmt.Sprintf("%v: %s%s%v", ErrDeferred, keysString(e.Trace), errSeparator, e.Err)
+}
+
+func (e *DeferredError) Unwrap() []error {
+	return []error{ErrDeferred, e.Err}
 }
 
 // ErrRegister indicates that an error occurred during registration, and wraps the error detail.
+// Errors of this kind are reported as [*RegisterError].
 var ErrRegister = fmt.Errorf("%w: register", Err)
 
-func newErrRegister(s *Scope, r Registrable, err error) error {
-	return fmt.Errorf("%w: %v <- %v%s%w", ErrRegister, s, r, errSeparator, err)
+// RegisterError is the [ErrRegister] reported for a specific registration.
+type RegisterError struct {
+	// Scope is the scope in which the registration was made.
+	Scope *Scope
+	// Module is the path of modules in which the registration was made (e.g., "app/storage/"), if any.
+	Module string
+	// Registrable is the failed registration.
+	Registrable Registrable
+	// Err is the error detail.
+	Err error
+}
+
+func newErrRegister(s *Scope, path string, r Registrable, err error) error {
+	return &RegisterError{s, path, r, err}
+}
+
+func (e *RegisterError) Error() string {
+	return fmt.Sprintf("%v: %v <- %s%v%s%v", ErrRegister, e.Scope, e.Module, e.Registrable, errSeparator, e.Err)
+}
+
+func (e *RegisterError) Unwrap() []error {
+	return []error{ErrRegister, e.Err}
 }
 
 // ErrResolve indicates that an error occurred during resolution, and wraps the error detail.
+// Errors of this kind are reported as [*ResolveError].
 var ErrResolve = fmt.Errorf("%w: resolve", Err)
 
-func newErrResolve(s *Scope, r reflect.Type, err error) error {
-	return fmt.Errorf("%w: %v -> %v%s%w", ErrResolve, s, r, errSeparator, err)
+// ResolveError is the [ErrResolve] reported for a specific resolution.
+type ResolveError struct {
+	// Scope is the scope in which the resolution was made.
+	Scope *Scope
+	// Type is the resolved type.
+	Type reflect.Type
+	// Name is the resolved name, if any.
+	Name string
+	// Source is the location of the resolved registration, if any (see [Source]).
+	Source Source
+	// Trace is the chain of resolutions leading to, and ending with, this resolution.
+	Trace []Key
+	// Err is the error detail.
+	Err error
+}
+
+func newErrResolve(s *Scope, b binding, t trace, err error) error {
+	return &ResolveError{s, b.t, b.name, b.source, append(t.keys(), Key{b.t, b.name}), err}
+}
+
+func (e *ResolveError) Error() string {
+	k := Key{e.Type, e.Name}
+	if e.Source == (Source{}) {
+		return fmt.Sprintf("%v: %v -> %v%s%v", ErrResolve, e.Scope, k, errSeparator, e.Err)
+	}
+	return fmt.Sprintf("%v: %v -> %v at %v%s%v", ErrResolve, e.Scope, k, e.Source, errSeparator, e.Err)
+}
+
+func (e *ResolveError) Unwrap() []error {
+	return []error{ErrResolve, e.Err}
+}
+
+// ErrValidate indicates that an error was found during validation, and wraps the error detail.
+// Errors of this kind are reported as [*ValidateError].
+var ErrValidate = fmt.Errorf("%w: validate", Err)
+
+// ValidateError is the [ErrValidate] reported for a specific problem.
+type ValidateError struct {
+	// Scope is the validated scope.
+	Scope *Scope
+	// Trace is the chain of dependencies leading to the problem.
+	Trace []Key
+	// Err is the error detail.
+	Err error
+}
+
+func newErrValidate(s *Scope, t trace, err error) error {
+	return &ValidateError{s, t.keys(), err}
+}
+
+func (e *ValidateError) Error() string {
+	return fmt.Sprintf("%v: %v -> %s%s%v", ErrValidate, e.Scope, keysString(e.Trace), errSeparator, e.Err)
+}
+
+func (e *ValidateError) Unwrap() []error {
+	return []error{ErrValidate, e.Err}
 }
 
 // ErrInvoke indicates that an error occurred during invocation, and wraps the error detail.
+// Errors of this kind are reported as [*InvokeError].
 var ErrInvoke = fmt.Errorf("%w: invoke", Err)
 
+// InvokeError is the [ErrInvoke] reported for a specific invocation.
+type InvokeError struct {
+	// Scope is the scope in which the invocation was made.
+	Scope *Scope
+	// Func is the type of the invoked function.
+	Func reflect.Type
+	// Err is the error detail.
+	Err error
+}
+
 func newErrInvoke(s *Scope, f reflect.Value, err error) error {
-	return fmt.Errorf("%w: %v <- %v%s%w", ErrInvoke, s, typeName(valueType(f)), errSeparator, err)
+	return &InvokeError{s, valueType(f), err}
+}
+
+func (e *InvokeError) Error() string {
+	return fmt.Sprintf("%v: %v <- %v%s%v", ErrInvoke, e.Scope, typeName(e.Func), errSeparator, e.Err)
+}
+
+func (e *InvokeError) Unwrap() []error {
+	return []error{ErrInvoke, e.Err}
+}
+
+// ErrInject indicates that an error occurred during injection, and wraps the error detail.
+// Errors of this kind are reported as [*InjectError].
+var ErrInject = fmt.Errorf("%w: inject", Err)
+
+// InjectError is the [ErrInject] reported for a specific field.
+type InjectError struct {
+	// Scope is the scope in which the injection was made.
+	Scope *Scope
+	// Path is the path of the injected field (e.g., "app.Config.Store").
+	Path string
+	// Err is the error detail.
+	Err error
+}
+
+func newErrInject(s *Scope, path string, err error) error {
+	return &InjectError{s, path, err}
+}
+
+func (e *InjectError) Error() string {
+	return fmt.Sprintf("%v: %v <- %s%s%v", ErrInject, e.Scope, e.Path, errSeparator, e.Err)
+}
+
+func (e *InjectError) Unwrap() []error {
+	return []error{ErrInject, e.Err}
+}
+
+// ErrDecorate indicates that an error occurred during decoration, and wraps the error detail.
+// Errors of this kind are reported as [*DecorateError].
+var ErrDecorate = fmt.Errorf("%w: decorate", Err)
+
+// DecorateError is the [ErrDecorate] reported for a specific decoration.
+type DecorateError struct {
+	// Scope is the scope in which the decoration was made.
+	Scope *Scope
+	// Decorate is the failed decoration.
+	Decorate DecorateBuilder
+	// Err is the error detail.
+	Err error
+}
+
+func newErrDecorate(s *Scope, d DecorateBuilder, err error) error {
+	return &DecorateError{s, d, err}
+}
+
+func (e *DecorateError) Error() string {
+	return fmt.Sprintf("%v: %v <- %v%s%v", ErrDecorate, e.Scope, e.Decorate, errSeparator, e.Err)
+}
+
+func (e *DecorateError) Unwrap() []error {
+	return []error{ErrDecorate, e.Err}
+}
+
+// ErrPanic indicates that a called function panicked.
+// Errors of this kind are reported as [*PanicError]. See [RecoverPanics].
+var ErrPanic = fmt.Errorf("%w: panic", Err)
+
+// PanicError is the [ErrPanic] reported for a specific panic.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the stack trace of the panicking goroutine.
+	Stack []byte
+}
+
+func newErrPanic(value any) error {
+	return &PanicError{value, debug.Stack()}
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%v: %v", ErrPanic, e.Value)
+}
+
+func (e *PanicError) Unwrap() error {
+	return ErrPanic
+}
+
+// ErrStart indicates that an error occurred while starting a value, and wraps the error detail.
+// Errors of this kind are reported as [*HookError]. See [Scope.Start].
+var ErrStart = fmt.Errorf("%w: start", Err)
+
+// ErrStop indicates that an error occurred while stopping a value, and wraps the error detail.
+// Errors of this kind are reported as [*HookError]. See [Scope.Stop].
+var ErrStop = fmt.Errorf("%w: stop", Err)
+
+// HookError is the [ErrStart] or [ErrStop] reported for a specific value.
+type HookError struct {
+	// Scope is the started or stopped scope.
+	Scope *Scope
+	// Hook is either [ErrStart] or [ErrStop].
+	Hook error
+	// Value is the started or stopped value.
+	Value any
+	// Source is the location of the registration which created the value (see [Source]).
+	Source Source
+	// Err is the error detail.
+	Err error
+}
+
+func newErrStart(s *Scope, h hook, err error) error {
+	return &HookError{s, ErrStart, h.value.Interface(), h.source, err}
+}
+
+func newErrStop(s *Scope, h hook, err error) error {
+	return &HookError{s, ErrStop, h.value.Interface(), h.source, err}
+}
+
+func (e *HookError) Error() string {
+	return fmt.Sprintf("%v: %v -> [%s] %v at %v%s%v",
+		e.Hook, e.Scope, typeName(reflect.TypeOf(e.Value)), e.Value, e.Source, errSeparator, e.Err)
+}
+
+func (e *HookError) Unwrap() []error {
+	return []error{e.Hook, e.Err}
 }
 
 // ErrDestroy indicates that an error occurred during destruction, and wraps the error detail.
+// Errors of this kind are reported as [*DestroyError].
 var ErrDestroy = fmt.Errorf("%w: destroy", Err)
 
+// DestroyError is the [ErrDestroy] reported for a specific destroyed value.
+type DestroyError struct {
+	// Scope is the destroyed scope.
+	Scope *Scope
+	// Value is the destroyed value.
+	Value any
+	// Source is the location of the registration which created the value (see [Source]).
+	Source Source
+	// Err is the error detail.
+	Err error
+}
+
 func newErrDestroy(s *Scope, d destroyer, err error) error {
-	return fmt.Errorf("%w: %v -> %v%s%w", ErrDestroy, s, d, errSeparator, err)
+	return &DestroyError{s, d.value.Interface(), d.source, err}
+}
+
+func (e *DestroyError) Error() string {
+	return fmt.Sprintf("%v: %v -> [%s] %v at %v%s%v",
+		ErrDestroy, e.Scope, typeName(reflect.TypeOf(e.Value)), e.Value, e.Source, errSeparator, e.Err)
+}
+
+func (e *DestroyError) Unwrap() []error {
+	return []error{ErrDestroy, e.Err}
 }
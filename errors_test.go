@@ -0,0 +1,191 @@
+package di_test
+
+import (
+	"errors"
+	"github.com/michaeljpetter/di"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestErrors(t *testing.T) {
+	type (
+		A struct{}
+		B struct{}
+		C struct{}
+	)
+
+	keyOf := func(t reflect.Type) di.Key { return di.Key{Type: t} }
+
+	t.Run("RegisterError", func(t *testing.T) {
+		s := di.NewScope("test")
+		f := di.Factory[A](nil)
+
+		err := s.Register(di.Module("mod", f))
+
+		var e *di.RegisterError
+		if assert.ErrorAs(t, err, &e) {
+			assert.Same(t, s, e.Scope)
+			assert.Equal(t, "mod/", e.Module)
+			assert.Equal(t, f, e.Registrable)
+			assert.ErrorIs(t, e.Err, di.ErrNil)
+		}
+		assert.ErrorIs(t, err, di.ErrRegister)
+		assert.ErrorIs(t, err, di.Err)
+	})
+
+	t.Run("ResolveError", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[A](func(B) A { return A{} }),
+			di.Factory[B](func(C) B { return B{} }).Named("b"),
+			di.Alias[B, B]().OfNamed("b"))
+
+		_, err := di.ResolveIn[A](s)
+
+		var e *di.ResolveError
+		if assert.ErrorAs(t, err, &e) {
+			assert.Same(t, s, e.Scope)
+			assert.Equal(t, reflect.TypeFor[A](), e.Type)
+			assert.Empty(t, e.Name)
+			assert.Equal(t, []di.Key{keyOf(reflect.TypeFor[A]())}, e.Trace)
+			assert.Contains(t, e.Source.File, "errors_test.go")
+		}
+
+		var n *di.NotRegisteredError
+		if assert.ErrorAs(t, err, &n) {
+			assert.Equal(t, keyOf(reflect.TypeFor[C]()), n.Key)
+			assert.Equal(t, []*di.Scope{s}, n.Searched)
+		}
+
+		var inner *di.ResolveError
+		assert.ErrorAs(t, e.Err, &inner)
+		assert.ErrorAs(t, inner.Err, &inner)
+		assert.ErrorAs(t, inner.Err, &inner)
+		assert.Equal(t, []di.Key{
+			keyOf(reflect.TypeFor[A]()),
+			keyOf(reflect.TypeFor[B]()),
+			{reflect.TypeFor[B](), "b"},
+			keyOf(reflect.TypeFor[C]()),
+		}, inner.Trace)
+		assert.Equal(t, di.Source{}, inner.Source)
+
+		assert.ErrorIs(t, err, di.ErrResolve)
+		assert.ErrorIs(t, err, di.ErrInvoke)
+		assert.ErrorIs(t, err, di.ErrNotRegistered)
+	})
+
+	t.Run("CycleError", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[A](func(B) A { return A{} }),
+			di.Factory[B](func(A) B { return B{} }))
+
+		_, err := di.ResolveIn[A](s)
+
+		var e *di.CycleError
+		if assert.ErrorAs(t, err, &e) {
+			assert.Equal(t, []di.Key{
+				keyOf(reflect.TypeFor[A]()),
+				keyOf(reflect.TypeFor[B]()),
+				keyOf(reflect.TypeFor[A]()),
+			}, e.Trace)
+		}
+		assert.ErrorIs(t, err, di.ErrCycle)
+	})
+
+	t.Run("InvokeError", func(t *testing.T) {
+		s := di.NewScope("test")
+
+		_, err := di.InvokeIn(s, func(A) {})
+
+		var e *di.InvokeError
+		if assert.ErrorAs(t, err, &e) {
+			assert.Same(t, s, e.Scope)
+			assert.Equal(t, reflect.TypeFor[func(A)](), e.Func)
+		}
+		assert.ErrorIs(t, err, di.ErrInvoke)
+	})
+
+	t.Run("DestroyError", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1).Destroy(func(int) error { return errors.New("failed") }))
+
+		err := s.Destroy()
+
+		var e *di.DestroyError
+		if assert.ErrorAs(t, err, &e) {
+			assert.Same(t, s, e.Scope)
+			assert.Equal(t, 1, e.Value)
+			assert.Contains(t, e.Source.File, "errors_test.go")
+			assert.EqualError(t, e.Err, "failed")
+		}
+		assert.ErrorIs(t, err, di.ErrDestroy)
+	})
+
+	t.Run("ValidateError", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[A](func(B) A { return A{} }))
+
+		err := s.Validate()
+
+		var e *di.ValidateError
+		if assert.ErrorAs(t, err, &e) {
+			assert.Same(t, s, e.Scope)
+			assert.Equal(t, []di.Key{keyOf(reflect.TypeFor[A]())}, e.Trace)
+		}
+		assert.ErrorIs(t, err, di.ErrValidate)
+		assert.ErrorIs(t, err, di.ErrNotRegistered)
+	})
+
+	t.Run("DeferredError", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[A](func(di.Lazy[B]) A { return A{} }))
+
+		lazy := di.MustResolveIn[di.Lazy[B]](s)
+		_, err := lazy.Get()
+
+		var e *di.DeferredError
+		if assert.ErrorAs(t, err, &e) {
+			assert.Equal(t, []di.Key{keyOf(reflect.TypeFor[di.Lazy[B]]())}, e.Trace)
+		}
+		assert.ErrorIs(t, err, di.ErrDeferred)
+		assert.ErrorIs(t, err, di.ErrNotRegistered)
+	})
+
+	t.Run("InjectError", func(t *testing.T) {
+		s := di.NewScope("test")
+
+		type target struct {
+			A A `di:""`
+		}
+		err := di.InjectIn(s, &target{})
+
+		var e *di.InjectError
+		if assert.ErrorAs(t, err, &e) {
+			assert.Same(t, s, e.Scope)
+			assert.Equal(t, "di_test.target.A", e.Path)
+		}
+		assert.ErrorIs(t, err, di.ErrInject)
+	})
+
+	t.Run("DecorateError", func(t *testing.T) {
+		s := di.NewScope("test")
+		d := di.Decorate[A](func(a A, _ B) A { return a })
+		s.MustRegister(
+			di.Instance[A](A{}),
+			d)
+
+		_, err := di.ResolveIn[A](s)
+
+		var e *di.DecorateError
+		if assert.ErrorAs(t, err, &e) {
+			assert.Same(t, s, e.Scope)
+			assert.Equal(t, d, e.Decorate)
+		}
+		assert.ErrorIs(t, err, di.ErrDecorate)
+	})
+}
@@ -1,47 +1,79 @@
 package di
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 )
 
-func factory(s *Scope, r reflect.Type, provider reflect.Type, create reflect.Value, destroy reflect.Value) error {
+func factory(s *Scope, b binding, provider reflect.Type, create reflect.Value, names []string, destroy reflect.Value) error {
+	r := b.t
+
 	v, err := validateCreate(r, create)
 	if err != nil {
 		return err
 	}
 
+	if err = validateNames("create", create, 0, names); err != nil {
+		return err
+	}
+
 	if err = validateDestroy(v, destroy); err != nil {
 		return err
 	}
 
-	s.registerProvider(r, reflect.MakeFunc(
-		provider,
-		func(args []reflect.Value) []reflect.Value {
-			resolver := args[0].Interface().(*Scope)
-			trace := args[1].Interface().(trace)
+	_, err = s.registerProvider(&node{
+		binding: b,
+		kind:    "Factory",
+		deps:    dependencies(create.Type(), 0, names),
+		provider: reflect.MakeFunc(
+			provider,
+			func(args []reflect.Value) []reflect.Value {
+				ctx := args[0].Interface().(context.Context)
+				resolver := args[1].Interface().(*Scope)
+				trace := args[2].Interface().(trace)
 
-			result := []reflect.Value{reflect.Zero(r), reflect.Zero(reflect.TypeFor[error]())}
+				result := []reflect.Value{reflect.Zero(r), reflect.Zero(reflect.TypeFor[error]())}
 
-			if out, err := resolver.invoke(create, trace); err != nil {
-				result[1] = reflect.ValueOf(err)
-			} else if 1 < len(out) && !out[1].IsNil() {
-				result[1] = out[1]
-			} else {
-				result[0] = out[0].Convert(r)
-				resolver.registerDestroyer(out[0], destroy)
-			}
+				if out, err := resolver.invoke(ctx, create, names, trace); err != nil {
+					result[1] = reflect.ValueOf(err)
+				} else if 1 < len(out) && !out[1].IsNil() {
+					result[1] = out[1]
+				} else {
+					result[0] = out[0].Convert(r)
+					resolver.registerDestroyer(out[0], destroy, b.source)
+				}
 
-			return result
-		},
-	))
+				return result
+			},
+		),
+	})
 
-	return nil
+	return err
 }
 
 // FactoryBuilder provides configuration of a [Factory].
 type FactoryBuilder interface {
 	Registrable
+	// Source returns the location at which this factory was defined.
+	Source() Source
+	// Named configures the name under which this factory is registered.
+	// See [ResolveNamedIn] for details.
+	Named(name string) FactoryBuilder
+	// Group configures this factory as a member of the group for its resolved type R,
+	// rather than as the sole registration of R. Resolving []R returns the values of
+	// all members of the group, in registration order.
+	Group() FactoryBuilder
+	// MapKey configures this factory as the entry with the given key in the map for its
+	// resolved type R, rather than as the sole registration of R. Resolving map[string]R
+	// returns the values of all entries of the map, with later registrations of a key
+	// taking precedence.
+	MapKey(key string) FactoryBuilder
+	// ArgNames configures the names of the registrations resolved for the
+	// parameters of the create function, in order. An empty name resolves
+	// the unnamed registration, as do any parameters beyond the given names.
+	// Parameter structs (see [In]) are configured by their tags, and must be given an empty name.
+	ArgNames(names ...string) FactoryBuilder
 	// Destroy configures a destroy function for the values created by this factory.
 	// See IsValidDestroy for details.
 	Destroy(destroy any) FactoryBuilder
@@ -56,19 +88,41 @@ type FactoryBuilder interface {
 //   - Dependencies are resolved from the scope in which the factory is being resolved.
 func Factory[R any](create any) FactoryBuilder {
 	return &factoryBuilder{
-		r:        reflect.TypeFor[R](),
+		binding:  binding{key: key{t: reflect.TypeFor[R]()}, source: callerSource()},
 		provider: reflect.TypeFor[provider[R]](),
 		create:   reflect.ValueOf(create),
 	}
 }
 
 type factoryBuilder struct {
-	r, provider     reflect.Type
+	binding
+	provider        reflect.Type
+	names           []string
 	create, destroy reflect.Value
 }
 
 func (b *factoryBuilder) String() string {
-	return fmt.Sprintf("Factory[%s]", typeName(b.r))
+	return fmt.Sprintf("Factory[%v] at %v", b.binding, b.source)
+}
+
+func (b *factoryBuilder) Named(name string) FactoryBuilder {
+	b.name = name
+	return b
+}
+
+func (b *factoryBuilder) Group() FactoryBuilder {
+	b.group, b.mapped = true, false
+	return b
+}
+
+func (b *factoryBuilder) MapKey(key string) FactoryBuilder {
+	b.group, b.mapped, b.mapKey = false, true, key
+	return b
+}
+
+func (b *factoryBuilder) ArgNames(names ...string) FactoryBuilder {
+	b.names = names
+	return b
 }
 
 func (b *factoryBuilder) Destroy(destroy any) FactoryBuilder {
@@ -77,5 +131,5 @@ func (b *factoryBuilder) Destroy(destroy any) FactoryBuilder {
 }
 
 func (b *factoryBuilder) register(s *Scope) error {
-	return factory(s, b.r, b.provider, b.create, b.destroy)
+	return factory(s, b.binding, b.provider, b.create, b.names, b.destroy)
 }
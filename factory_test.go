@@ -2,6 +2,7 @@ package di_test
 
 import (
 	"errors"
+	"fmt"
 	"github.com/michaeljpetter/di"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -90,4 +91,58 @@ func TestFactory(t *testing.T) {
 
 		s.MustDestroy()
 	})
+
+	t.Run("Named", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[int](rotate(1)),
+			di.Factory[int](rotate(2)).Named("two"))
+
+		assert.Equal(t, 1, di.MustResolveIn[int](s))
+		assert.Equal(t, 2, di.MustResolveNamedIn[int](s, "two"))
+
+		_, err := di.ResolveNamedIn[int](s, "three")
+		assert.ErrorIs(t, err, di.ErrNotRegistered)
+		assert.ErrorContains(t, err, `int "three"`)
+
+		s.MustDestroy()
+	})
+
+	t.Run("ArgNames", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1),
+			di.Instance[int](2).Named("two"),
+			di.Factory[string](func(a, b, c int) string { return fmt.Sprint(a, b, c) }).
+				ArgNames("", "two"))
+
+		assert.Equal(t, "1 2 1", di.MustResolveIn[string](s))
+
+		s.MustDestroy()
+	})
+
+	t.Run("ArgNamesNotRegistered", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[string](func(int) string { return "" }).
+				ArgNames("two"))
+
+		_, err := di.ResolveIn[string](s)
+		assert.ErrorIs(t, err, di.ErrNotRegistered)
+		assert.ErrorContains(t, err, `resolve: test -> int "two"`)
+
+		s.MustDestroy()
+	})
+
+	t.Run("InvalidArgNames", func(t *testing.T) {
+		s := di.NewScope("test")
+
+		err := s.Register(
+			di.Factory[int](func(string) int { return 0 }).
+				ArgNames("a", "b"))
+
+		assert.ErrorIs(t, err, di.ErrInvalidNames)
+
+		s.MustDestroy()
+	})
 }
@@ -0,0 +1,264 @@
+package di
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Graph describes the registrations which may be resolved within a scope,
+// and the dependencies between them. See [Scope.Graph].
+type Graph struct {
+	// Scope is the name of the scope described by the graph.
+	Scope string `json:"scope"`
+	// Nodes are the registrations, ordered by ID.
+	Nodes []GraphNode `json:"nodes"`
+	// Edges are the dependencies of the registrations, ordered by node and then by parameter.
+	Edges []GraphEdge `json:"edges"`
+}
+
+// GraphNode describes a single registration within a [Graph].
+type GraphNode struct {
+	// ID identifies the registration by its resolved type and name.
+	ID string `json:"id"`
+	// Type is the resolved type.
+	Type string `json:"type"`
+	// Name is the registered name, if any.
+	Name string `json:"name,omitempty"`
+	// Kind is the builder by which the registration was made (e.g., "Factory"),
+	// or "Group" or "Map" for a collection of members.
+	Kind string `json:"kind"`
+	// Scope is the name of the scope in which the registration was made.
+	Scope string `json:"scope"`
+	// Decorated reports whether the registration is wrapped by any [Decorate].
+	Decorated bool `json:"decorated,omitempty"`
+}
+
+// GraphEdge describes a single dependency within a [Graph].
+type GraphEdge struct {
+	// From is the ID of the dependent node.
+	From string `json:"from"`
+	// To is the ID of the dependency, which is not a node of the graph if it is not registered.
+	To string `json:"to"`
+	// Optional reports whether the dependency resolves the zero value if it is not registered.
+	Optional bool `json:"optional,omitempty"`
+	// Group reports whether the dependency resolves an empty collection if it is not registered.
+	Group bool `json:"group,omitempty"`
+	// Deferred reports whether the dependency is resolved after creation (e.g., [Lazy]).
+	Deferred bool `json:"deferred,omitempty"`
+	// Unresolvable reports whether the dependency is required, but not registered.
+	Unresolvable bool `json:"unresolvable,omitempty"`
+}
+
+// Graph returns the registrations which may be resolved within the scope, along with their
+// dependencies (i.e., the parameters of create functions and the targets of aliases),
+// without creating any values.
+func (s *Scope) Graph() *Graph {
+	g := &Graph{Scope: s.name, Nodes: []GraphNode{}, Edges: []GraphEdge{}}
+
+	for _, k := range s.keys() {
+		n, owner := s.lookupOwner(k)
+
+		node := GraphNode{
+			ID:    k.String(),
+			Type:  typeName(k.t),
+			Name:  k.name,
+			Kind:  n.kind,
+			Scope: owner.name,
+		}
+
+		for base := n.decorated; base != nil; base = base.decorated {
+			node.Kind, node.Decorated = base.kind, true
+		}
+
+		g.Nodes = append(g.Nodes, node)
+		g.Edges = append(g.Edges, s.graphEdges(node.ID, n)...)
+	}
+
+	return g
+}
+
+func (s *Scope) lookupOwner(k key) (*node, *Scope) {
+	for scope := s; scope != nil; scope = scope.parent {
+		scope.providersLock.RLock()
+		n, ok := scope.providers[k]
+		scope.providersLock.RUnlock()
+
+		if ok {
+			return n, scope
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *Scope) graphEdges(from string, n *node) []GraphEdge {
+	var edges []GraphEdge
+
+	var visit func(*node)
+	visit = func(n *node) {
+		scope := s
+		if n.home != nil {
+			scope = n.home
+		}
+
+		for _, d := range n.deps {
+			if d.key == contextKey {
+				continue
+			}
+
+			if d.t.Implements(reflect.TypeFor[resolvable]()) {
+				d = reflect.Zero(d.t).Interface().(resolvable).dependency(d.key)
+			}
+
+			dep, _ := scope.lookup(d.key)
+
+			edge := GraphEdge{
+				From:         from,
+				To:           d.key.String(),
+				Optional:     d.optional,
+				Group:        d.group,
+				Deferred:     d.deferred,
+				Unresolvable: dep == nil && !d.optional && !d.group,
+			}
+
+			if !slices.Contains(edges, edge) {
+				edges = append(edges, edge)
+			}
+		}
+
+		if n.decorated != nil {
+			visit(n.decorated)
+		}
+
+		if n.owner != nil {
+			for _, m := range n.owner.collectionMembers(n.key) {
+				visit(m)
+			}
+		}
+	}
+
+	visit(n)
+	return edges
+}
+
+func (g *Graph) missing() []string {
+	nodes := make(map[string]bool, len(g.Nodes))
+	for _, n := range g.Nodes {
+		nodes[n.ID] = true
+	}
+
+	var missing []string
+	for _, e := range g.Edges {
+		if !nodes[e.To] && !slices.Contains(missing, e.To) {
+			missing = append(missing, e.To)
+		}
+	}
+
+	return missing
+}
+
+// WriteDOT writes the graph to w in the Graphviz DOT language.
+// Unresolvable dependencies are drawn in red, and optional or deferred dependencies are dashed.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	b := bufio.NewWriter(w)
+
+	fmt.Fprintf(b, "digraph %q {\n", g.Scope)
+	fmt.Fprintf(b, "\tnode [shape=box];\n")
+
+	for _, n := range g.Nodes {
+		fmt.Fprintf(b, "\t%q [label=%q];\n", n.ID, n.ID+"\n"+n.label())
+	}
+
+	for _, id := range g.missing() {
+		fmt.Fprintf(b, "\t%q [style=dashed];\n", id)
+	}
+
+	for _, e := range g.Edges {
+		var attrs []string
+		if e.Optional || e.Deferred {
+			attrs = append(attrs, "style=dashed")
+		}
+		if e.Unresolvable {
+			attrs = append(attrs, "color=red")
+		}
+
+		if len(attrs) == 0 {
+			fmt.Fprintf(b, "\t%q -> %q;\n", e.From, e.To)
+		} else {
+			fmt.Fprintf(b, "\t%q -> %q [%s];\n", e.From, e.To, strings.Join(attrs, ", "))
+		}
+	}
+
+	fmt.Fprintf(b, "}\n")
+	return b.Flush()
+}
+
+// WriteMermaid writes the graph to w as a Mermaid flowchart.
+// Unresolvable dependencies are drawn in red, and optional or deferred dependencies are dotted.
+func (g *Graph) WriteMermaid(w io.Writer) error {
+	b := bufio.NewWriter(w)
+
+	ids := make(map[string]string)
+	id := func(s string) string {
+		if _, ok := ids[s]; !ok {
+			ids[s] = fmt.Sprintf("n%d", len(ids))
+		}
+		return ids[s]
+	}
+
+	fmt.Fprintf(b, "flowchart LR\n")
+
+	for _, n := range g.Nodes {
+		fmt.Fprintf(b, "\t%s[\"%s<br/>%s\"]\n", id(n.ID), mermaidEscape(n.ID), mermaidEscape(n.label()))
+	}
+
+	missing := g.missing()
+	for _, m := range missing {
+		fmt.Fprintf(b, "\t%s[\"%s\"]:::missing\n", id(m), mermaidEscape(m))
+	}
+
+	var unresolvable []string
+	for i, e := range g.Edges {
+		arrow := "-->"
+		if e.Optional || e.Deferred {
+			arrow = "-.->"
+		}
+		if e.Unresolvable {
+			unresolvable = append(unresolvable, fmt.Sprint(i))
+		}
+
+		fmt.Fprintf(b, "\t%s %s %s\n", id(e.From), arrow, id(e.To))
+	}
+
+	if 0 < len(missing) {
+		fmt.Fprintf(b, "\tclassDef missing stroke-dasharray:4\n")
+	}
+	if 0 < len(unresolvable) {
+		fmt.Fprintf(b, "\tlinkStyle %s stroke:red\n", strings.Join(unresolvable, ","))
+	}
+
+	return b.Flush()
+}
+
+// WriteJSON writes the graph to w as JSON.
+func (g *Graph) WriteJSON(w io.Writer) error {
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(g)
+}
+
+func (n GraphNode) label() string {
+	if n.Decorated {
+		return fmt.Sprintf("%s (decorated) in %s", n.Kind, n.Scope)
+	}
+	return fmt.Sprintf("%s in %s", n.Kind, n.Scope)
+}
+
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;").Replace(s)
+}
@@ -0,0 +1,109 @@
+package di_test
+
+import (
+	"encoding/json"
+	"github.com/michaeljpetter/di"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestGraph(t *testing.T) {
+	type (
+		A struct{}
+		B struct{}
+		C struct{}
+		D struct{}
+	)
+
+	newScope := func() *di.Scope {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[A](func(B, di.Optional[C], di.Lazy[D]) A { return A{} }),
+			di.Singleton[B](func(int) B { return B{} }),
+			di.Alias[float64, int]().Named("f"),
+			di.Instance[string]("a").Group(),
+			di.Decorate[B](func(b B, _ []string) B { return b }))
+
+		c := s.NewChild("child")
+		c.MustRegister(
+			di.Instance[C](C{}))
+		return c
+	}
+
+	t.Run("Nodes", func(t *testing.T) {
+		g := newScope().Graph()
+
+		assert.Equal(t, "child", g.Scope)
+		assert.Equal(t, []di.GraphNode{
+			{ID: "[]string", Type: "[]string", Kind: "Group", Scope: "test"},
+			{ID: "di_test.A", Type: "di_test.A", Kind: "Factory", Scope: "test"},
+			{ID: "di_test.B", Type: "di_test.B", Kind: "Singleton", Scope: "test", Decorated: true},
+			{ID: "di_test.C", Type: "di_test.C", Kind: "Instance", Scope: "child"},
+			{ID: `float64 "f"`, Type: "float64", Name: "f", Kind: "Alias", Scope: "test"},
+		}, g.Nodes)
+	})
+
+	t.Run("Edges", func(t *testing.T) {
+		g := newScope().Graph()
+
+		assert.Equal(t, []di.GraphEdge{
+			{From: "di_test.A", To: "di_test.B"},
+			{From: "di_test.A", To: "di_test.C", Optional: true},
+			{From: "di_test.A", To: "di_test.D", Deferred: true, Unresolvable: true},
+			{From: "di_test.B", To: "[]string"},
+			{From: "di_test.B", To: "int", Unresolvable: true},
+			{From: `float64 "f"`, To: "int", Unresolvable: true},
+		}, g.Edges)
+	})
+
+	t.Run("WriteDOT", func(t *testing.T) {
+		var b strings.Builder
+		assert.NoError(t, newScope().Graph().WriteDOT(&b))
+
+		dot := b.String()
+		assert.True(t, strings.HasPrefix(dot, "digraph \"child\" {\n"))
+		assert.Contains(t, dot, "\t\"di_test.A\" [label=\"di_test.A\\nFactory in test\"];\n")
+		assert.Contains(t, dot, "\t\"di_test.B\" [label=\"di_test.B\\nSingleton (decorated) in test\"];\n")
+		assert.Contains(t, dot, "\t\"int\" [style=dashed];\n")
+		assert.Contains(t, dot, "\t\"di_test.A\" -> \"di_test.B\";\n")
+		assert.Contains(t, dot, "\t\"di_test.A\" -> \"di_test.C\" [style=dashed];\n")
+		assert.Contains(t, dot, "\t\"float64 \\\"f\\\"\" -> \"int\" [color=red];\n")
+		assert.True(t, strings.HasSuffix(dot, "}\n"))
+	})
+
+	t.Run("WriteMermaid", func(t *testing.T) {
+		var b strings.Builder
+		assert.NoError(t, newScope().Graph().WriteMermaid(&b))
+
+		mermaid := b.String()
+		assert.True(t, strings.HasPrefix(mermaid, "flowchart LR\n"))
+		assert.Contains(t, mermaid, "\tn1[\"di_test.A<br/>Factory in test\"]\n")
+		assert.Contains(t, mermaid, "\tn4[\"float64 #quot;f#quot;<br/>Alias in test\"]\n")
+		assert.Contains(t, mermaid, "\tn5[\"di_test.D\"]:::missing\n")
+		assert.Contains(t, mermaid, "\tn1 --> n2\n")
+		assert.Contains(t, mermaid, "\tn1 -.-> n3\n")
+		assert.Contains(t, mermaid, "\tlinkStyle 2,4,5 stroke:red\n")
+	})
+
+	t.Run("WriteJSON", func(t *testing.T) {
+		var b strings.Builder
+		assert.NoError(t, newScope().Graph().WriteJSON(&b))
+
+		var g di.Graph
+		assert.NoError(t, json.Unmarshal([]byte(b.String()), &g))
+		assert.Equal(t, newScope().Graph(), &g)
+		assert.Contains(t, b.String(), `"unresolvable": true`)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		g := di.NewScope("test").Graph()
+
+		assert.Empty(t, g.Nodes)
+		assert.Empty(t, g.Edges)
+
+		var b strings.Builder
+		assert.NoError(t, g.WriteJSON(&b))
+		assert.JSONEq(t, `{"scope": "test", "nodes": [], "edges": []}`, b.String())
+	})
+}
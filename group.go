@@ -0,0 +1,116 @@
+package di
+
+import (
+	"reflect"
+	"slices"
+)
+
+// collectionKey returns the key of the collection (i.e., group or map) of a member.
+func collectionKey(b binding) key {
+	if b.mapped {
+		return key{reflect.MapOf(reflect.TypeFor[string](), b.t), b.name}
+	}
+	return key{reflect.SliceOf(b.t), b.name}
+}
+
+// resolutionKey returns the key by which a registration is resolved,
+// which for a member is the key of its collection.
+func resolutionKey(b binding) key {
+	if b.group || b.mapped {
+		return collectionKey(b)
+	}
+	return b.key
+}
+
+func (s *Scope) registerMember(n *node) (bool, error) {
+	c := collectionKey(n.binding)
+	kind := "Group"
+	if n.mapped {
+		kind = "Map"
+	}
+
+	members := s.members[c]
+
+	if len(members) == 0 {
+		if ok, err := s.setProvider(&node{
+			binding:  binding{key: c},
+			kind:     kind,
+			provider: collection(s, n.key, c),
+			owner:    s,
+		}); !ok {
+			return false, err
+		}
+	}
+
+	if i := slices.IndexFunc(members, func(m *node) bool { return n.mapped && m.mapKey == n.mapKey }); 0 <= i {
+		switch s.onDuplicate {
+		case Reject:
+			return false, newErrDuplicate(n.binding)
+		case Keep:
+			return false, nil
+		}
+
+		members[i] = n
+		return true, nil
+	}
+
+	s.members[c] = append(members, n)
+	return true, nil
+}
+
+func (s *Scope) collectionMembers(c key) []*node {
+	var members []*node
+
+	for scope := s; scope != nil; scope = scope.parent {
+		scope.providersLock.RLock()
+		members = append(slices.Clone(scope.members[c]), members...)
+		scope.providersLock.RUnlock()
+	}
+
+	return members
+}
+
+func collection(s *Scope, k key, c key) reflect.Value {
+	return reflect.MakeFunc(
+		providerOf(c.t),
+		func(args []reflect.Value) []reflect.Value {
+			resolver := args[1].Interface().(*Scope)
+			members := s.collectionMembers(c)
+
+			if c.t.Kind() == reflect.Map {
+				// entries superseded by those of a child scope are not created
+				latest := make(map[string]*node, len(members))
+				for _, m := range members {
+					latest[m.mapKey] = m
+				}
+				members = slices.DeleteFunc(members, func(m *node) bool { return latest[m.mapKey] != m })
+			}
+
+			result := []reflect.Value{reflect.Zero(c.t), reflect.Zero(reflect.TypeFor[error]())}
+
+			values := reflect.MakeSlice(reflect.SliceOf(k.t), len(members), len(members))
+
+			for i, m := range members {
+				out := m.provider.Call(args)
+
+				if err, _ := out[1].Interface().(error); err != nil {
+					result[1] = reflect.ValueOf(newErrResolve(resolver, m.binding, args[2].Interface().(trace), err))
+					return result
+				}
+
+				values.Index(i).Set(out[0])
+			}
+
+			if c.t.Kind() == reflect.Slice {
+				result[0] = values
+			} else {
+				result[0] = reflect.MakeMapWithSize(c.t, len(members))
+				for i, m := range members {
+					result[0].SetMapIndex(reflect.ValueOf(m.mapKey), values.Index(i))
+				}
+			}
+
+			return result
+		},
+	)
+}
@@ -0,0 +1,229 @@
+package di_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/michaeljpetter/di"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGroup(t *testing.T) {
+	t.Run("Minimal", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1).Group(),
+			di.Factory[int](rotate(2, 3)).Group(),
+			di.Singleton[int](rotate(4, 5)).Group(),
+			di.Scoped[int](rotate(6, 7)).Group(),
+			di.Instance[float64](8.9),
+			di.Alias[int, float64]().Group())
+
+		assert.Equal(t, []int{1, 2, 4, 6, 8}, di.MustResolveIn[[]int](s))
+		assert.Equal(t, []int{1, 3, 4, 6, 8}, di.MustResolveIn[[]int](s))
+
+		s.MustDestroy()
+	})
+
+	t.Run("Named", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1).Group(),
+			di.Instance[int](2).Named("two").Group(),
+			di.Instance[int](3).Named("two").Group())
+
+		assert.Equal(t, []int{1}, di.MustResolveIn[[]int](s))
+		assert.Equal(t, []int{2, 3}, di.MustResolveNamedIn[[]int](s, "two"))
+
+		s.MustDestroy()
+	})
+
+	t.Run("Separate", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1).Group(),
+			di.Instance[int](2))
+
+		assert.Equal(t, []int{1}, di.MustResolveIn[[]int](s))
+		assert.Equal(t, 2, di.MustResolveIn[int](s))
+
+		s.MustDestroy()
+	})
+
+	t.Run("Child", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1).Group())
+
+		c := s.NewChild("child")
+		c.MustRegister(
+			di.Instance[int](2).Group())
+
+		s.MustRegister(
+			di.Instance[int](3).Group())
+
+		assert.Equal(t, []int{1, 3}, di.MustResolveIn[[]int](s))
+		assert.Equal(t, []int{1, 3, 2}, di.MustResolveIn[[]int](c))
+		assert.Equal(t, []int{1, 3}, di.MustResolveIn[[]int](s.NewChild("other")))
+
+		s.MustDestroy()
+	})
+
+	t.Run("Dependent", func(t *testing.T) {
+		type sum int
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1).Group(),
+			di.Instance[int](2).Group(),
+			di.Factory[sum](func(values []int) (n sum) {
+				for _, v := range values {
+					n += sum(v)
+				}
+				return
+			}))
+
+		assert.EqualValues(t, 3, di.MustResolveIn[sum](s))
+
+		s.MustDestroy()
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1).Group(),
+			di.Factory[int](func() (int, error) { return 2, errors.New("whoops") }).Group())
+
+		value, err := di.ResolveIn[[]int](s)
+		assert.Nil(t, value)
+		assert.ErrorIs(t, err, di.ErrResolve)
+		assert.ErrorContains(t, err, "whoops")
+
+		s.MustDestroy()
+	})
+
+	t.Run("NotRegistered", func(t *testing.T) {
+		s := di.NewScope("test")
+
+		_, err := di.ResolveIn[[]int](s)
+		assert.ErrorIs(t, err, di.ErrNotRegistered)
+
+		s.MustDestroy()
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[int](func([]int) int { return 0 }).Group())
+
+		_, err := di.ResolveIn[[]int](s)
+		assert.ErrorIs(t, err, di.ErrCycle)
+		assert.ErrorContains(t, err, ": []int -> []int")
+
+		s.MustDestroy()
+	})
+}
+
+func TestMap(t *testing.T) {
+	t.Run("Minimal", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1).MapKey("a"),
+			di.Factory[int](rotate(2, 3)).MapKey("b"),
+			di.Singleton[int](rotate(4, 5)).MapKey("c"),
+			di.Scoped[int](rotate(6, 7)).MapKey("d"),
+			di.Instance[float64](8.9),
+			di.Alias[int, float64]().MapKey("e"))
+
+		assert.Equal(t, map[string]int{"a": 1, "b": 2, "c": 4, "d": 6, "e": 8}, di.MustResolveIn[map[string]int](s))
+		assert.Equal(t, map[string]int{"a": 1, "b": 3, "c": 4, "d": 6, "e": 8}, di.MustResolveIn[map[string]int](s))
+
+		s.MustDestroy()
+	})
+
+	t.Run("Named", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1).MapKey("a"),
+			di.Instance[int](2).Named("two").MapKey("a"),
+			di.Instance[int](3).Named("two").MapKey("b"))
+
+		assert.Equal(t, map[string]int{"a": 1}, di.MustResolveIn[map[string]int](s))
+		assert.Equal(t, map[string]int{"a": 2, "b": 3}, di.MustResolveNamedIn[map[string]int](s, "two"))
+
+		s.MustDestroy()
+	})
+
+	t.Run("Precedence", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1).MapKey("a"),
+			di.Instance[int](2).MapKey("b"),
+			di.Instance[int](3).MapKey("a"))
+
+		c := s.NewChild("child")
+		c.MustRegister(
+			di.Instance[int](4).MapKey("b"))
+
+		assert.Equal(t, map[string]int{"a": 3, "b": 2}, di.MustResolveIn[map[string]int](s))
+		assert.Equal(t, map[string]int{"a": 3, "b": 4}, di.MustResolveIn[map[string]int](c))
+
+		s.MustDestroy()
+	})
+
+	t.Run("Replaced", func(t *testing.T) {
+		var created []string
+		create := func(v string) func() string {
+			return func() string { created = append(created, v); return v }
+		}
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Singleton[string](create("old")).MapKey("a"),
+			di.Singleton[string](create("b")).MapKey("b"),
+			di.Singleton[string](create("new")).MapKey("a"))
+
+		c := s.NewChild("child")
+		c.MustRegister(
+			di.Singleton[string](create("child")).MapKey("b"))
+
+		assert.Equal(t, map[string]string{"a": "new", "b": "child"}, di.MustResolveIn[map[string]string](c))
+		assert.Equal(t, []string{"new", "child"}, created)
+
+		s.MustDestroy()
+	})
+
+	t.Run("Dependent", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1).MapKey("a"),
+			di.Instance[int](2).Group(),
+			di.Factory[string](func(m map[string]int, g []int) string { return fmt.Sprint(m, g) }))
+
+		assert.Equal(t, "map[a:1] [2]", di.MustResolveIn[string](s))
+
+		s.MustDestroy()
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1).MapKey("a"),
+			di.Factory[int](func() (int, error) { return 2, errors.New("whoops") }).MapKey("b"))
+
+		value, err := di.ResolveIn[map[string]int](s)
+		assert.Nil(t, value)
+		assert.ErrorIs(t, err, di.ErrResolve)
+		assert.ErrorContains(t, err, "whoops")
+
+		s.MustDestroy()
+	})
+
+	t.Run("NotRegistered", func(t *testing.T) {
+		s := di.NewScope("test")
+
+		_, err := di.ResolveIn[map[string]int](s)
+		assert.ErrorIs(t, err, di.ErrNotRegistered)
+
+		s.MustDestroy()
+	})
+}
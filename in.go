@@ -0,0 +1,194 @@
+package di
+
+import (
+	"context"
+	"reflect"
+	"strings"
+)
+
+// In marks a parameter struct when embedded within it.
+// A parameter struct may be used as any parameter of a create function
+// (or any other function whose parameters are resolved, such as with [InvokeIn]).
+// Rather than resolving the struct itself, each of its exported fields is resolved
+// as a dependency, and the struct is passed with the fields set.
+//
+//	type Params struct {
+//		di.In
+//		Primary *sql.DB
+//		Replica *sql.DB  `di:"name=replica"`
+//		Tracer  Tracer   `di:"optional"`
+//		Routes  []Route  `di:"group"`
+//	}
+//
+// Fields may be configured with a di tag of comma-separated options:
+//   - name=N resolves the registration with name N.
+//   - optional resolves the zero value if the field type is not registered.
+//   - group resolves a group (slice) or map of registrations, which is empty if none are registered.
+//
+// Parameter structs must not have unexported fields, other than the embedded In.
+type In struct{}
+
+func isIn(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := range t.NumField() {
+		if f := t.Field(i); f.Anonymous && f.Type == reflect.TypeFor[In]() {
+			return true
+		}
+	}
+
+	return false
+}
+
+type dependency struct {
+	key
+	optional, group, deferred bool
+}
+
+type field struct {
+	index int
+	dependency
+}
+
+type param struct {
+	dependency
+	in     reflect.Type
+	fields []field
+}
+
+func parseTag(parent reflect.Type, f reflect.StructField) (dependency, error) {
+	d := dependency{key: key{t: f.Type}}
+
+	tag, ok := f.Tag.Lookup("di")
+	if !ok || tag == "" {
+		return d, nil
+	}
+
+	for _, option := range strings.Split(tag, ",") {
+		switch name, ok := strings.CutPrefix(option, "name="); {
+		case ok && name != "":
+			d.name = name
+		case option == "optional":
+			d.optional = true
+		case option == "group":
+			if f.Type.Kind() != reflect.Slice &&
+				(f.Type.Kind() != reflect.Map || f.Type.Key() != reflect.TypeFor[string]()) {
+				return d, newErrInvalidField(parent, f)
+			}
+			d.group = true
+		default:
+			return d, newErrInvalidField(parent, f)
+		}
+	}
+
+	return d, nil
+}
+
+func inFields(t reflect.Type) ([]field, error) {
+	var fields []field
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+
+		if f.Anonymous && f.Type == reflect.TypeFor[In]() {
+			continue
+		}
+		if !f.IsExported() {
+			return nil, newErrInvalidField(t, f)
+		}
+
+		d, err := parseTag(t, f)
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, field{i, d})
+	}
+
+	return fields, nil
+}
+
+func params(f reflect.Type, given int, names []string) ([]param, error) {
+	params := make([]param, 0, f.NumIn()-given)
+
+	for i := given; i < f.NumIn(); i++ {
+		t := f.In(i)
+
+		if isIn(t) {
+			fields, err := inFields(t)
+			if err != nil {
+				return nil, err
+			}
+			params = append(params, param{in: t, fields: fields})
+			continue
+		}
+
+		p := param{dependency: dependency{key: key{t: t}}}
+		if n := i - given; n < len(names) {
+			p.name = names[n]
+		}
+		params = append(params, p)
+	}
+
+	return params, nil
+}
+
+func dependencies(f reflect.Type, given int, names []string) []dependency {
+	params, _ := params(f, given, names)
+
+	var deps []dependency
+	for _, p := range params {
+		if p.in == nil {
+			deps = append(deps, p.dependency)
+			continue
+		}
+		for _, f := range p.fields {
+			deps = append(deps, f.dependency)
+		}
+	}
+
+	return deps
+}
+
+func (s *Scope) resolveParam(ctx context.Context, p param, trace trace) (reflect.Value, error) {
+	if p.in == nil {
+		return s.resolveDependency(ctx, p.dependency, trace)
+	}
+
+	value := reflect.New(p.in).Elem()
+
+	for _, f := range p.fields {
+		v, err := s.resolveDependency(ctx, f.dependency, trace)
+		if err != nil {
+			return reflect.Zero(p.in), err
+		}
+		value.Field(f.index).Set(v)
+	}
+
+	return value, nil
+}
+
+func (s *Scope) resolveDependency(ctx context.Context, d dependency, trace trace) (reflect.Value, error) {
+	// the context and types which define their own resolution are never registered,
+	// thus must not be looked up
+	if d.key == contextKey || d.t.Implements(reflect.TypeFor[resolvable]()) {
+		return s.resolve(ctx, d.key, trace)
+	}
+
+	if d.optional || d.group {
+		if n, _ := s.lookup(d.key); n == nil {
+			switch {
+			case !d.group:
+				return reflect.Zero(d.t), nil
+			case d.t.Kind() == reflect.Slice:
+				return reflect.MakeSlice(d.t, 0, 0), nil
+			default:
+				return reflect.MakeMap(d.t), nil
+			}
+		}
+	}
+
+	return s.resolve(ctx, d.key, trace)
+}
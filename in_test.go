@@ -0,0 +1,214 @@
+package di_test
+
+import (
+	"errors"
+	"github.com/michaeljpetter/di"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIn(t *testing.T) {
+	type params struct {
+		di.In
+		Int      int
+		Two      int            `di:"name=two"`
+		Float    float64        `di:"optional"`
+		String   string         `di:"optional,name=str"`
+		Group    []int          `di:"group"`
+		Map      map[string]int `di:"group"`
+		Optional di.Optional[int]
+	}
+
+	t.Run("Full", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1),
+			di.Instance[int](2).Named("two"),
+			di.Instance[float64](3.4),
+			di.Instance[string]("five").Named("str"),
+			di.Instance[int](6).Group(),
+			di.Instance[int](7).Group(),
+			di.Instance[int](8).MapKey("a"),
+			di.Factory[params](func(p params) params { return p }))
+
+		assert.Equal(t, params{
+			Int:      1,
+			Two:      2,
+			Float:    3.4,
+			String:   "five",
+			Group:    []int{6, 7},
+			Map:      map[string]int{"a": 8},
+			Optional: di.MustResolveIn[di.Optional[int]](s),
+		}, di.MustResolveIn[params](s))
+
+		s.MustDestroy()
+	})
+
+	t.Run("Optional", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1),
+			di.Instance[int](2).Named("two"),
+			di.Factory[params](func(p params) params { return p }))
+
+		assert.Equal(t, params{
+			Int:      1,
+			Two:      2,
+			Group:    []int{},
+			Map:      map[string]int{},
+			Optional: di.MustResolveIn[di.Optional[int]](s),
+		}, di.MustResolveIn[params](s))
+
+		s.MustDestroy()
+	})
+
+	t.Run("OptionalResolvable", func(t *testing.T) {
+		type in struct {
+			di.In
+			L di.Lazy[int]     `di:"optional"`
+			O di.Optional[int] `di:"optional"`
+		}
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1))
+
+		p := di.MustInvokeIn(s, func(p in) in { return p })[0].(in)
+
+		value, err := p.L.Get()
+		assert.Equal(t, 1, value)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, p.O.OrElse(0))
+
+		s.MustDestroy()
+	})
+
+	t.Run("Mixed", func(t *testing.T) {
+		type in struct {
+			di.In
+			B int `di:"name=b"`
+		}
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1),
+			di.Instance[int](2).Named("b"),
+			di.Instance[int](3).Named("c"),
+			di.Factory[[]int](func(a int, p in, c int) []int { return []int{a, p.B, c} }).
+				ArgNames("", "", "c"))
+
+		assert.Equal(t, []int{1, 2, 3}, di.MustResolveIn[[]int](s))
+
+		s.MustDestroy()
+	})
+
+	t.Run("InvokeIn", func(t *testing.T) {
+		type in struct {
+			di.In
+			A int
+			B int `di:"name=b"`
+		}
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1),
+			di.Instance[int](2).Named("b"))
+
+		values, err := di.InvokeIn(s, func(p in) int { return p.A + p.B })
+		assert.Equal(t, []any{3}, values)
+		assert.NoError(t, err)
+
+		s.MustDestroy()
+	})
+
+	t.Run("NotRegistered", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1),
+			di.Factory[params](func(p params) params { return p }))
+
+		_, err := di.ResolveIn[params](s)
+		assert.ErrorIs(t, err, di.ErrNotRegistered)
+		assert.ErrorContains(t, err, `int "two"`)
+
+		s.MustDestroy()
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1),
+			di.Instance[int](2).Named("two"),
+			di.Factory[float64](func() (float64, error) { return 0, errors.New("whoops") }),
+			di.Factory[params](func(p params) params { return p }))
+
+		_, err := di.ResolveIn[params](s)
+		assert.ErrorIs(t, err, di.ErrInvoke)
+		assert.ErrorContains(t, err, "whoops")
+
+		s.MustDestroy()
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for name, create := range map[string]any{
+			"UnknownOption": func(struct {
+				di.In
+				A int `di:"required"`
+			}) int {
+				return 0
+			},
+			"EmptyName": func(struct {
+				di.In
+				A int `di:"name="`
+			}) int {
+				return 0
+			},
+			"GroupNotCollection": func(struct {
+				di.In
+				A int `di:"group"`
+			}) int {
+				return 0
+			},
+			"GroupNotStringMap": func(struct {
+				di.In
+				A map[int]int `di:"group"`
+			}) int {
+				return 0
+			},
+			"Unexported": func(struct {
+				di.In
+				a int
+			}) int {
+				return 0
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				s := di.NewScope("test")
+
+				assert.False(t, di.IsValidCreate[int](create))
+
+				err := s.Register(di.Factory[int](create))
+				assert.ErrorIs(t, err, di.ErrRegister)
+				assert.ErrorIs(t, err, di.ErrInvalidField)
+
+				_, err = di.InvokeIn(s, create)
+				assert.ErrorIs(t, err, di.ErrInvoke)
+				assert.ErrorIs(t, err, di.ErrInvalidField)
+
+				s.MustDestroy()
+			})
+		}
+	})
+
+	t.Run("InvalidArgNames", func(t *testing.T) {
+		s := di.NewScope("test")
+
+		err := s.Register(
+			di.Factory[params](func(p params) params { return p }).
+				ArgNames("p"))
+
+		assert.ErrorIs(t, err, di.ErrInvalidNames)
+
+		s.MustDestroy()
+	})
+}
@@ -0,0 +1,236 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// initOrder returns the given eager nodes in dependency order, along with the eager nodes
+// on which each depends (directly, or through other registrations). Dependencies which
+// would form a cycle are omitted, such that waiting on them cannot deadlock.
+func (s *Scope) initOrder(eager []*node) ([]*node, map[*node][]*node) {
+	isEager := make(map[*node]bool, len(eager))
+	for _, n := range eager {
+		isEager[n] = true
+	}
+
+	deps := make(map[*node][]*node, len(eager))
+	for _, n := range eager {
+		deps[n] = s.eagerDependencies(n, isEager)
+	}
+
+	var order []*node
+	visited := make(map[*node]bool, len(eager))
+
+	var visit func(*node)
+	visit = func(n *node) {
+		if visited[n] {
+			return
+		}
+		visited[n] = true
+		for _, d := range deps[n] {
+			visit(d)
+		}
+		order = append(order, n)
+	}
+
+	for _, n := range eager {
+		visit(n)
+	}
+
+	position := make(map[*node]int, len(order))
+	for i, n := range order {
+		position[n] = i
+	}
+
+	for _, n := range order {
+		deps[n] = slices.DeleteFunc(deps[n], func(d *node) bool {
+			return position[n] < position[d]
+		})
+	}
+
+	return order, deps
+}
+
+func (s *Scope) eagerDependencies(root *node, isEager map[*node]bool) []*node {
+	var found []*node
+	visited := make(map[*node]bool)
+
+	var visit func(*Scope, *node)
+	visit = func(scope *Scope, n *node) {
+		if visited[n] {
+			return
+		}
+		visited[n] = true
+
+		if n != root && isEager[n] {
+			found = append(found, n)
+			return
+		}
+
+		from := scope
+		if n.home != nil {
+			from = n.home
+		}
+
+		for _, d := range n.deps {
+			if d.key == contextKey {
+				continue
+			}
+			if d.t.Implements(reflect.TypeFor[resolvable]()) {
+				d = reflect.Zero(d.t).Interface().(resolvable).dependency(d.key)
+			}
+			if d.deferred {
+				continue
+			}
+			if m, _ := from.lookup(d.key); m != nil {
+				visit(from, m)
+			}
+		}
+
+		if n.decorated != nil {
+			visit(scope, n.decorated)
+		}
+
+		if n.owner != nil {
+			for _, m := range n.owner.collectionMembers(n.key) {
+				visit(scope, m)
+			}
+		}
+	}
+
+	visit(s, root)
+	return found
+}
+
+// initGroups partitions the given eager nodes (by index) into groups which are created concurrently,
+// the nodes of each group being created one at a time, in order. Nodes from which a cycle may be reached
+// are grouped together, as concurrent creations could otherwise deadlock on the singletons within the cycle.
+func (s *Scope) initGroups(order []*node) [][]int {
+	var groups [][]int
+	var cyclic []int
+
+	for i, n := range order {
+		if s.reachesCycle(n) {
+			cyclic = append(cyclic, i)
+		} else {
+			groups = append(groups, []int{i})
+		}
+	}
+
+	if 0 < len(cyclic) {
+		groups = append(groups, cyclic)
+	}
+
+	return groups
+}
+
+// reachesCycle reports whether a cycle may be reached in resolving a node registered within the scope.
+func (s *Scope) reachesCycle(n *node) bool {
+	v := validation{root: s, visited: make(map[visit]bool)}
+	v.visitKey(s, resolutionKey(n.binding), n, nil)
+
+	return slices.ContainsFunc(v.errs, func(err error) bool {
+		return errors.Is(err, ErrCycle)
+	})
+}
+
+// initNode creates the value of an eager node once its eager dependencies have been created,
+// within one of the given slots.
+func (s *Scope) initNode(n *node, deps []*node, done map[*node]chan struct{}, slots chan struct{}) error {
+	defer close(done[n])
+
+	for _, d := range deps {
+		<-done[d]
+	}
+
+	slots <- struct{}{}
+	defer func() { <-slots }()
+
+	return s.resolveNode(context.Background(), n)
+}
+
+// Init creates the values of all eager singletons registered within the scope
+// (see [SingletonBuilder.Eager]), and returns [ErrResolve] for every one that fails.
+//   - Values are created in dependency order, as each value's dependencies are created before it.
+//   - Values which do not depend on one another are created concurrently,
+//     up to the limit configured by [InitConcurrency]. Values whose dependencies form a cycle
+//     are created one at a time, such that the cycle is reported rather than deadlocking.
+//   - A failure does not prevent the creation of the remaining values.
+//   - Values already created are not created again.
+//   - Registrations which have since been replaced (see [Replace]) are not created.
+func (s *Scope) Init() error {
+	s.lifecycleLock.Lock()
+	eager := slices.Clone(s.eager)
+	s.lifecycleLock.Unlock()
+
+	// registrations which have since been replaced are not created
+	eager = slices.DeleteFunc(eager, func(n *node) bool { return !s.active(n) })
+
+	order, deps := s.initOrder(eager)
+	errs := make([]error, len(order))
+
+	if s.initConcurrency == 1 {
+		for i, n := range order {
+			errs[i] = s.resolveNode(context.Background(), n)
+		}
+		return errors.Join(errs...)
+	}
+
+	limit := s.initConcurrency
+	if limit < 1 {
+		limit = len(order)
+	}
+
+	var (
+		wg        sync.WaitGroup
+		panicOnce sync.Once
+		panicked  bool
+		recovered any
+	)
+
+	slots := make(chan struct{}, limit)
+	done := make(map[*node]chan struct{}, len(order))
+	for _, n := range order {
+		done[n] = make(chan struct{})
+	}
+
+	initNode := func(i int) {
+		// any panic is raised again in the initializing goroutine
+		defer func() {
+			if r := recover(); r != nil {
+				panicOnce.Do(func() { panicked, recovered = true, r })
+			}
+		}()
+
+		errs[i] = s.initNode(order[i], deps[order[i]], done, slots)
+	}
+
+	for _, group := range s.initGroups(order) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, i := range group {
+				initNode(i)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if panicked {
+		panic(recovered)
+	}
+
+	return errors.Join(errs...)
+}
+
+// MustInit is like [Scope.Init] but panics on error.
+func (s *Scope) MustInit() {
+	if err := s.Init(); err != nil {
+		panic(err)
+	}
+}
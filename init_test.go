@@ -0,0 +1,263 @@
+package di_test
+
+import (
+	"errors"
+	"github.com/michaeljpetter/di"
+	"github.com/stretchr/testify/assert"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestInit(t *testing.T) {
+	type (
+		A struct{}
+		B struct{}
+		C struct{}
+		D struct{}
+	)
+
+	t.Run("Eager", func(t *testing.T) {
+		var created []string
+
+		s := di.NewScope("test", di.InitConcurrency(1))
+		s.MustRegister(
+			di.Singleton[A](func(B) A { created = append(created, "A"); return A{} }).Eager(),
+			di.Singleton[B](func(C) B { created = append(created, "B"); return B{} }).Eager(),
+			di.Singleton[C](func() C { created = append(created, "C"); return C{} }),
+			di.Singleton[D](func() D { created = append(created, "D"); return D{} }),
+			di.Singleton[int](func() int { created = append(created, "int"); return 1 }).Eager().Group())
+
+		assert.NoError(t, s.Init())
+		assert.Equal(t, []string{"C", "B", "A", "int"}, created)
+
+		assert.NoError(t, s.Init())
+		assert.Equal(t, []string{"C", "B", "A", "int"}, created)
+
+		assert.Equal(t, []int{1}, di.MustResolveIn[[]int](s))
+		assert.Equal(t, []string{"C", "B", "A", "int"}, created)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		var created []string
+
+		s := di.NewScope("test", di.InitConcurrency(1))
+		s.MustRegister(
+			di.Singleton[A](func(D) A { created = append(created, "A"); return A{} }).Eager(),
+			di.Singleton[B](func() (B, error) { return B{}, errors.New("failed") }).Eager(),
+			di.Singleton[C](func() C { created = append(created, "C"); return C{} }).Eager())
+
+		err := s.Init()
+		assert.ErrorIs(t, err, di.ErrResolve)
+		assert.ErrorIs(t, err, di.ErrNotRegistered)
+		assert.ErrorContains(t, err, "resolve: test -> di_test.A at ")
+		assert.ErrorContains(t, err, "resolve: test -> di_test.B at ")
+		assert.ErrorContains(t, err, "failed")
+		assert.Equal(t, []string{"C"}, created)
+
+		assert.Panics(t, func() { s.MustInit() })
+	})
+
+	t.Run("Child", func(t *testing.T) {
+		var created []string
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Singleton[A](func() A { created = append(created, "A"); return A{} }).Eager())
+
+		c := s.NewChild("child")
+		c.MustRegister(
+			di.Singleton[B](func(A) B { created = append(created, "B"); return B{} }).Eager())
+
+		assert.NoError(t, c.Init())
+		assert.Equal(t, []string{"A", "B"}, created)
+
+		assert.NoError(t, s.Init())
+		assert.Equal(t, []string{"A", "B"}, created)
+	})
+
+	t.Run("Replaced", func(t *testing.T) {
+		var created []string
+		create := func(v string) func() string {
+			return func() string { created = append(created, v); return v }
+		}
+
+		s := di.NewScope("test", di.InitConcurrency(1))
+		s.MustRegister(
+			di.Singleton[string](create("old")).Eager(),
+			di.Singleton[string](create("new")).Eager(),
+			di.Singleton[string](create("old a")).MapKey("a").Eager(),
+			di.Singleton[string](create("new a")).MapKey("a").Eager())
+
+		assert.NoError(t, s.Init())
+		assert.Equal(t, []string{"new", "new a"}, created)
+		assert.Equal(t, "new", di.MustResolveIn[string](s))
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		// each blocks until all three are being created at once
+		var barrier sync.WaitGroup
+		barrier.Add(3)
+		wait := func() error {
+			barrier.Done()
+			ok := make(chan struct{})
+			go func() { barrier.Wait(); close(ok) }()
+			select {
+			case <-ok:
+				return nil
+			case <-time.After(time.Second):
+				return errors.New("not concurrent")
+			}
+		}
+
+		s := di.NewScope("test", di.InitConcurrency(0))
+		s.MustRegister(
+			di.Singleton[A](func() (A, error) { return A{}, wait() }).Eager(),
+			di.Singleton[B](func() (B, error) { return B{}, wait() }).Eager(),
+			di.Singleton[C](func() (C, error) { return C{}, wait() }).Eager())
+
+		assert.NoError(t, s.Init())
+	})
+
+	t.Run("DefaultConcurrency", func(t *testing.T) {
+		defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(2))
+
+		// each blocks until both are being created at once
+		var barrier sync.WaitGroup
+		barrier.Add(2)
+		wait := func() error {
+			barrier.Done()
+			ok := make(chan struct{})
+			go func() { barrier.Wait(); close(ok) }()
+			select {
+			case <-ok:
+				return nil
+			case <-time.After(time.Second):
+				return errors.New("not concurrent")
+			}
+		}
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Singleton[A](func() (A, error) { return A{}, wait() }).Eager(),
+			di.Singleton[B](func() (B, error) { return B{}, wait() }).Eager())
+
+		assert.NoError(t, s.Init())
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		var current, peak atomic.Int32
+		create := func() int {
+			n := current.Add(1)
+			for p := peak.Load(); p < n && !peak.CompareAndSwap(p, n); p = peak.Load() {
+			}
+			time.Sleep(10 * time.Millisecond)
+			current.Add(-1)
+			return 0
+		}
+
+		s := di.NewScope("test", di.InitConcurrency(2))
+		s.MustRegister(
+			di.Singleton[int](create).Eager().Group(),
+			di.Singleton[int](create).Eager().Group(),
+			di.Singleton[int](create).Eager().Group(),
+			di.Singleton[int](create).Eager().Group(),
+			di.Singleton[int](create).Eager().Group())
+
+		assert.NoError(t, s.Init())
+		assert.Equal(t, int32(2), peak.Load())
+	})
+
+	t.Run("DependencyOrder", func(t *testing.T) {
+		var (
+			lock    sync.Mutex
+			created []string
+		)
+		create := func(name string) {
+			time.Sleep(5 * time.Millisecond)
+			lock.Lock()
+			created = append(created, name)
+			lock.Unlock()
+		}
+
+		s := di.NewScope("test", di.InitConcurrency(0))
+		s.MustRegister(
+			di.Singleton[A](func(di.Optional[B], []C) A { create("A"); return A{} }).Eager(),
+			di.Factory[B](func(D) B { return B{} }),
+			di.Singleton[C](func() C { create("C"); return C{} }).Eager().Group(),
+			di.Singleton[D](func() D { create("D"); return D{} }).Eager(),
+			di.Decorate[D](func(d D) D { return d }))
+
+		assert.NoError(t, s.Init())
+		assert.Len(t, created, 3)
+		assert.Equal(t, "A", created[2])
+	})
+
+	t.Run("ConcurrentErrors", func(t *testing.T) {
+		s := di.NewScope("test", di.InitConcurrency(0))
+		s.MustRegister(
+			di.Singleton[A](func(B) A { return A{} }).Eager(),
+			di.Singleton[B](func() (B, error) { return B{}, errors.New("failed B") }).Eager(),
+			di.Singleton[C](func() (C, error) { return C{}, errors.New("failed C") }).Eager(),
+			di.Singleton[D](func() D { return D{} }).Eager())
+
+		err := s.Init()
+		assert.ErrorIs(t, err, di.ErrResolve)
+		assert.ErrorContains(t, err, "resolve: test -> di_test.A at ")
+		assert.ErrorContains(t, err, "failed B")
+		assert.ErrorContains(t, err, "failed C")
+		assert.NotContains(t, err.Error(), "di_test.D at ")
+	})
+
+	t.Run("ConcurrentCycle", func(t *testing.T) {
+		s := di.NewScope("test", di.InitConcurrency(0))
+		s.MustRegister(
+			di.Singleton[A](func(B) A { return A{} }).Eager(),
+			di.Singleton[B](func(A) B { return B{} }).Eager())
+
+		err := s.Init()
+		assert.ErrorIs(t, err, di.ErrCycle)
+	})
+
+	t.Run("ConcurrentIndirectCycle", func(t *testing.T) {
+		s := di.NewScope("test", di.InitConcurrency(0))
+		s.MustRegister(
+			di.Singleton[A](func(C) A { return A{} }).Eager(),
+			di.Singleton[B](func(D) B { return B{} }).Eager(),
+			di.Singleton[C](func(int, D) C { return C{} }),
+			di.Singleton[D](func(int, C) D { return D{} }),
+			// the dependency is slow, such that both cyclic singletons are being created at once
+			di.Factory[int](func() int { time.Sleep(20 * time.Millisecond); return 0 }))
+
+		done := make(chan error, 1)
+		go func() { done <- s.Init() }()
+
+		select {
+		case err := <-done:
+			assert.ErrorIs(t, err, di.ErrCycle)
+		case <-time.After(time.Second):
+			t.Fatal("init deadlocked")
+		}
+	})
+
+	t.Run("GroupDependsOnType", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1),
+			di.Singleton[int](func(i int) int { return i + 1 }).Eager().Group())
+
+		assert.NoError(t, s.Init())
+		assert.Equal(t, []int{2}, di.MustResolveIn[[]int](s))
+	})
+
+	t.Run("ConcurrentPanic", func(t *testing.T) {
+		s := di.NewScope("test", di.InitConcurrency(0))
+		s.MustRegister(
+			di.Singleton[A](func() A { panic("boom") }).Eager(),
+			di.Singleton[B](func() B { return B{} }).Eager())
+
+		assert.PanicsWithValue(t, "boom", func() { s.Init() })
+	})
+}
@@ -0,0 +1,76 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+func (s *Scope) inject(value reflect.Value, path string) []error {
+	var errs []error
+	t := value.Type()
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+		fieldPath := path + "." + f.Name
+
+		if _, ok := f.Tag.Lookup("di"); !ok {
+			if f.Anonymous && f.Type.Kind() == reflect.Struct {
+				errs = append(errs, s.inject(value.Field(i), fieldPath)...)
+			}
+			continue
+		}
+
+		if !f.IsExported() {
+			errs = append(errs, newErrInject(s, fieldPath, newErrInvalidField(t, f)))
+			continue
+		}
+
+		d, err := parseTag(t, f)
+		if err != nil {
+			errs = append(errs, newErrInject(s, fieldPath, err))
+			continue
+		}
+
+		v, err := s.resolveDependency(context.Background(), d, nil)
+		if err != nil {
+			errs = append(errs, newErrInject(s, fieldPath, err))
+			continue
+		}
+
+		value.Field(i).Set(v)
+	}
+
+	return errs
+}
+
+// InjectIn sets the fields of the given target struct by resolving them
+// as dependencies within the given scope.
+// The target must be a non-nil pointer to a struct.
+//
+// Only fields with a di tag are set, which may be empty, or configured
+// with the same options as the fields of a parameter struct (see [In]).
+// Embedded structs without a di tag are injected recursively.
+// [ErrInject] is returned for each field that cannot be set.
+func InjectIn(s *Scope, target any) error {
+	v := reflect.ValueOf(target)
+
+	if !v.IsValid() {
+		return newErrNil("target")
+	}
+	if v.Kind() != reflect.Pointer || v.Type().Elem().Kind() != reflect.Struct {
+		return newErrNotStructPointer("target", v)
+	}
+	if v.IsNil() {
+		return newErrNil("target")
+	}
+
+	return errors.Join(s.inject(v.Elem(), typeName(v.Type().Elem()))...)
+}
+
+// MustInjectIn is like [InjectIn] but panics on error.
+func MustInjectIn(s *Scope, target any) {
+	if err := InjectIn(s, target); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,134 @@
+package di_test
+
+import (
+	"errors"
+	"github.com/michaeljpetter/di"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestInjectIn(t *testing.T) {
+	type Embedded struct {
+		Float float64 `di:""`
+	}
+
+	type target struct {
+		Embedded
+		Int      int            `di:""`
+		Two      int            `di:"name=two"`
+		String   string         `di:"optional"`
+		Group    []int          `di:"group"`
+		Map      map[string]int `di:"group"`
+		Untagged int
+	}
+
+	t.Run("Full", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1),
+			di.Instance[int](2).Named("two"),
+			di.Instance[float64](3.4),
+			di.Instance[string]("five"),
+			di.Instance[int](6).Group(),
+			di.Instance[int](7).MapKey("a"))
+
+		v := target{Untagged: 8}
+		assert.NoError(t, di.InjectIn(s, &v))
+
+		assert.Equal(t, target{
+			Embedded: Embedded{3.4},
+			Int:      1,
+			Two:      2,
+			String:   "five",
+			Group:    []int{6},
+			Map:      map[string]int{"a": 7},
+			Untagged: 8,
+		}, v)
+
+		s.MustDestroy()
+	})
+
+	t.Run("Optional", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1),
+			di.Instance[int](2).Named("two"),
+			di.Instance[float64](3.4))
+
+		v := target{String: "keep"}
+		assert.NoError(t, di.InjectIn(s, &v))
+
+		assert.Equal(t, target{
+			Embedded: Embedded{3.4},
+			Int:      1,
+			Two:      2,
+			Group:    []int{},
+			Map:      map[string]int{},
+		}, v)
+
+		s.MustDestroy()
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[int](func() (int, error) { return 0, errors.New("whoops") }))
+
+		var v target
+		err := di.InjectIn(s, &v)
+		assert.ErrorIs(t, err, di.ErrInject)
+		assert.ErrorIs(t, err, di.ErrNotRegistered)
+		assert.ErrorContains(t, err, "whoops")
+		assert.ErrorContains(t, err, "inject: test <- di_test.target.Embedded.Float")
+		assert.ErrorContains(t, err, "inject: test <- di_test.target.Int")
+		assert.ErrorContains(t, err, "inject: test <- di_test.target.Two")
+
+		s.MustDestroy()
+	})
+
+	t.Run("InvalidField", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1))
+
+		var v struct {
+			A int `di:"nope"`
+			b int `di:""`
+			C int `di:""`
+		}
+		err := di.InjectIn(s, &v)
+		assert.ErrorIs(t, err, di.ErrInject)
+		assert.ErrorIs(t, err, di.ErrInvalidField)
+		assert.ErrorContains(t, err, ".A")
+		assert.ErrorContains(t, err, ".b")
+		assert.Equal(t, 1, v.C)
+
+		s.MustDestroy()
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		s := di.NewScope("test")
+
+		assert.ErrorIs(t, di.InjectIn(s, nil), di.ErrNil)
+		assert.ErrorIs(t, di.InjectIn(s, (*target)(nil)), di.ErrNil)
+	})
+
+	t.Run("NotStructPointer", func(t *testing.T) {
+		s := di.NewScope("test")
+
+		assert.ErrorIs(t, di.InjectIn(s, target{}), di.ErrNotStructPointer)
+		assert.ErrorIs(t, di.InjectIn(s, new(int)), di.ErrNotStructPointer)
+	})
+
+	t.Run("MustInjectIn", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1))
+
+		var v struct {
+			A int `di:""`
+		}
+		assert.NotPanics(t, func() { di.MustInjectIn(s, &v) })
+		assert.Panics(t, func() { di.MustInjectIn(s, v) })
+	})
+}
@@ -5,7 +5,9 @@ import (
 	"reflect"
 )
 
-func instance(s *Scope, r reflect.Type, provider reflect.Type, value reflect.Value, destroy reflect.Value) error {
+func instance(s *Scope, b binding, provider reflect.Type, value reflect.Value, destroy, start, stop reflect.Value) error {
+	r := b.t
+
 	value, err := validateValue(r, value)
 	if err != nil {
 		return err
@@ -15,26 +17,63 @@ func instance(s *Scope, r reflect.Type, provider reflect.Type, value reflect.Val
 		return err
 	}
 
+	if err = validateHook("start", value.Type(), start); err != nil {
+		return err
+	}
+
+	if err = validateHook("stop", value.Type(), stop); err != nil {
+		return err
+	}
+
 	result := []reflect.Value{value.Convert(r), reflect.Zero(reflect.TypeFor[error]())}
 
-	s.registerProvider(r, reflect.MakeFunc(
-		provider,
-		func([]reflect.Value) []reflect.Value {
-			return result
-		},
-	))
+	n := &node{
+		binding: b,
+		kind:    "Instance",
+		provider: reflect.MakeFunc(
+			provider,
+			func([]reflect.Value) []reflect.Value {
+				return result
+			},
+		),
+	}
+
+	ok, err := s.registerProvider(n)
 
-	s.registerDestroyer(value, destroy)
+	if ok {
+		s.registerDestroyer(value, destroy, b.source)
+		s.registerHook(n, value, start, stop, b.source)
+	}
 
-	return nil
+	return err
 }
 
 // InstanceBuilder provides configuration of an [Instance].
 type InstanceBuilder interface {
 	Registrable
+	// Source returns the location at which this instance was defined.
+	Source() Source
+	// Named configures the name under which this instance is registered.
+	// See [ResolveNamedIn] for details.
+	Named(name string) InstanceBuilder
+	// Group configures this instance as a member of the group for its resolved type R,
+	// rather than as the sole registration of R. Resolving []R returns the values of
+	// all members of the group, in registration order.
+	Group() InstanceBuilder
+	// MapKey configures this instance as the entry with the given key in the map for its
+	// resolved type R, rather than as the sole registration of R. Resolving map[string]R
+	// returns the values of all entries of the map, with later registrations of a key
+	// taking precedence.
+	MapKey(key string) InstanceBuilder
 	// Destroy configures a destroy function for the value associated with this instance.
 	// See IsValidDestroy for details.
 	Destroy(destroy any) InstanceBuilder
+	// OnStart configures a start function for the value associated with this instance,
+	// which is called by [Scope.Start]. See IsValidDestroy for the valid forms of the function.
+	OnStart(start any) InstanceBuilder
+	// OnStop configures a stop function for the value associated with this instance,
+	// which is called by [Scope.Stop]. See IsValidDestroy for the valid forms of the function.
+	OnStop(stop any) InstanceBuilder
 }
 
 // Instance defines an externally created value.
@@ -43,19 +82,35 @@ type InstanceBuilder interface {
 // Instance returns its fixed value each time it is resolved.
 func Instance[R any](value any) InstanceBuilder {
 	return &instanceBuilder{
-		r:        reflect.TypeFor[R](),
+		binding:  binding{key: key{t: reflect.TypeFor[R]()}, source: callerSource()},
 		provider: reflect.TypeFor[provider[R]](),
 		value:    reflect.ValueOf(value),
 	}
 }
 
 type instanceBuilder struct {
-	r, provider    reflect.Type
-	value, destroy reflect.Value
+	binding
+	provider                    reflect.Type
+	value, destroy, start, stop reflect.Value
 }
 
 func (b *instanceBuilder) String() string {
-	return fmt.Sprintf("Instance[%s]", typeName(b.r))
+	return fmt.Sprintf("Instance[%v] at %v", b.binding, b.source)
+}
+
+func (b *instanceBuilder) Named(name string) InstanceBuilder {
+	b.name = name
+	return b
+}
+
+func (b *instanceBuilder) Group() InstanceBuilder {
+	b.group, b.mapped = true, false
+	return b
+}
+
+func (b *instanceBuilder) MapKey(key string) InstanceBuilder {
+	b.group, b.mapped, b.mapKey = false, true, key
+	return b
 }
 
 func (b *instanceBuilder) Destroy(destroy any) InstanceBuilder {
@@ -63,6 +118,16 @@ func (b *instanceBuilder) Destroy(destroy any) InstanceBuilder {
 	return b
 }
 
+func (b *instanceBuilder) OnStart(start any) InstanceBuilder {
+	b.start = reflect.ValueOf(start)
+	return b
+}
+
+func (b *instanceBuilder) OnStop(stop any) InstanceBuilder {
+	b.stop = reflect.ValueOf(stop)
+	return b
+}
+
 func (b *instanceBuilder) register(s *Scope) error {
-	return instance(s, b.r, b.provider, b.value, b.destroy)
+	return instance(s, b.binding, b.provider, b.value, b.destroy, b.start, b.stop)
 }
@@ -55,4 +55,16 @@ func TestInstance(t *testing.T) {
 
 		s.MustDestroy()
 	})
+
+	t.Run("Named", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[intT](1),
+			di.Instance[intT](2).Named("two"))
+
+		assert.EqualValues(t, 1, di.MustResolveIn[intT](s))
+		assert.EqualValues(t, 2, di.MustResolveNamedIn[intT](s, "two"))
+
+		s.MustDestroy()
+	})
 }
@@ -0,0 +1,93 @@
+package di
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// Lazy is a deferred dependency on type T, which is resolved the first time it is requested.
+// It may be used in place of T anywhere a dependency is resolved,
+// such as the parameters of a create function, or with [ResolveIn].
+//
+// Lazy resolves T from the scope in which the Lazy was resolved.
+//   - Cycles through a Lazy are permitted, as T is not resolved until requested.
+//     However, requesting T during the creation of a value on which T depends returns [ErrCycle].
+//   - Errors are wrapped in [ErrDeferred] with the trace at which the Lazy was resolved.
+//   - The name of a named dependency applies to T.
+type Lazy[T any] struct {
+	get func() (T, error)
+}
+
+// Get resolves T on the first call, and returns the same result every time thereafter.
+func (l Lazy[T]) Get() (T, error) {
+	if l.get == nil {
+		var zero T
+		return zero, newErrNil("lazy")
+	}
+	return l.get()
+}
+
+func (Lazy[T]) resolveIn(ctx context.Context, s *Scope, k key, trace trace) (reflect.Value, error) {
+	t, origin := key{reflect.TypeFor[T](), k.name}, append(slices.Clone(trace), k)
+
+	return reflect.ValueOf(Lazy[T]{sync.OnceValues(func() (T, error) {
+		return resolveDeferred[T](ctx, s, t, origin)
+	})}), nil
+}
+
+func (Lazy[T]) dependency(k key) dependency {
+	return dependency{key: key{reflect.TypeFor[T](), k.name}, deferred: true}
+}
+
+// Provider is a deferred dependency on type T, which is resolved each time it is requested.
+// It may be used in place of T anywhere a dependency is resolved,
+// such as the parameters of a create function, or with [ResolveIn].
+//
+// Provider resolves T from the scope in which the Provider was resolved.
+//   - Cycles through a Provider are permitted, as T is not resolved until requested.
+//     However, requesting T during the creation of a value on which T depends returns [ErrCycle].
+//   - Errors are wrapped in [ErrDeferred] with the trace at which the Provider was resolved.
+//   - The name of a named dependency applies to T.
+type Provider[T any] struct {
+	get func() (T, error)
+}
+
+// Get resolves T on every call.
+func (p Provider[T]) Get() (T, error) {
+	if p.get == nil {
+		var zero T
+		return zero, newErrNil("provider")
+	}
+	return p.get()
+}
+
+func (Provider[T]) resolveIn(ctx context.Context, s *Scope, k key, trace trace) (reflect.Value, error) {
+	t, origin := key{reflect.TypeFor[T](), k.name}, append(slices.Clone(trace), k)
+
+	return reflect.ValueOf(Provider[T]{func() (T, error) {
+		return resolveDeferred[T](ctx, s, t, origin)
+	}}), nil
+}
+
+func (Provider[T]) dependency(k key) dependency {
+	return dependency{key: key{reflect.TypeFor[T](), k.name}, deferred: true}
+}
+
+func resolveDeferred[T any](ctx context.Context, s *Scope, k key, origin trace) (T, error) {
+	// while the value which requested T is still being created, T is resolved as its dependency,
+	// such that a cycle is reported rather than recursing without end
+	var trace trace
+	if c, ok := ctx.(*creation); ok && !c.done.Load() {
+		trace = origin
+	}
+
+	value, err := s.resolve(context.WithoutCancel(outerContext(ctx)), k, trace)
+	if err != nil {
+		err = newErrDeferred(origin, err)
+	}
+
+	iface, _ := value.Interface().(T)
+	return iface, err
+}
@@ -0,0 +1,233 @@
+package di_test
+
+import (
+	"errors"
+	"github.com/michaeljpetter/di"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type (
+	lazyA     struct{ b di.Lazy[*lazyB] }
+	lazyB     struct{ a *lazyA }
+	providerA struct{ b di.Provider[*providerB] }
+	providerB struct{ a *providerA }
+)
+
+func TestLazy(t *testing.T) {
+	t.Run("Minimal", func(t *testing.T) {
+		calls := 0
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[int](func() int { calls++; return calls }))
+
+		lazy := di.MustResolveIn[di.Lazy[int]](s)
+		assert.Zero(t, calls)
+
+		for range 3 {
+			value, err := lazy.Get()
+			assert.Equal(t, 1, value)
+			assert.NoError(t, err)
+		}
+
+		s.MustDestroy()
+	})
+
+	t.Run("Named", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](5).Named("five"))
+
+		value, err := di.MustResolveNamedIn[di.Lazy[int]](s, "five").Get()
+		assert.Equal(t, 5, value)
+		assert.NoError(t, err)
+
+		s.MustDestroy()
+	})
+
+	t.Run("ResolvingScope", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](5),
+			di.Factory[di.Lazy[int]](func(l di.Lazy[int]) di.Lazy[int] { return l }))
+
+		c := s.NewChild("child")
+		c.MustRegister(
+			di.Instance[int](7))
+
+		value, _ := di.MustResolveIn[di.Lazy[int]](c).Get()
+		assert.Equal(t, 7, value)
+
+		s.MustDestroy()
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Singleton[*lazyA](func(b di.Lazy[*lazyB]) *lazyA { return &lazyA{b} }),
+			di.Singleton[*lazyB](func(a *lazyA) *lazyB { return &lazyB{a} }))
+
+		a := di.MustResolveIn[*lazyA](s)
+		b, err := a.b.Get()
+		assert.NoError(t, err)
+		assert.Same(t, a, b.a)
+
+		s.MustDestroy()
+	})
+
+	t.Run("CycleDuringCreation", func(t *testing.T) {
+		type (
+			A struct{}
+			B struct{}
+		)
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Singleton[A](func(b di.Lazy[B]) (A, error) { _, err := b.Get(); return A{}, err }),
+			di.Singleton[B](func(A) B { return B{} }))
+
+		done := make(chan error, 1)
+		go func() { _, err := di.ResolveIn[A](s); done <- err }()
+
+		select {
+		case err := <-done:
+			assert.ErrorIs(t, err, di.ErrDeferred)
+			assert.ErrorIs(t, err, di.ErrCycle)
+		case <-time.After(time.Second):
+			t.Fatal("resolve deadlocked")
+		}
+
+		s.MustDestroy()
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		type A struct{}
+
+		errs := rotate(errors.New("whoops"), errors.New("floops"))
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[int](func() (int, error) { return 0, errs() }),
+			di.Factory[A](func(di.Lazy[int]) A { return A{} }))
+
+		var lazy di.Lazy[int]
+		di.MustInvokeIn(s, func(_ A, l di.Lazy[int]) { lazy = l })
+
+		for range 3 {
+			_, err := lazy.Get()
+			assert.ErrorIs(t, err, di.ErrDeferred)
+			assert.ErrorIs(t, err, di.ErrResolve)
+			assert.ErrorContains(t, err, "whoops")
+		}
+
+		s.MustDestroy()
+	})
+
+	t.Run("Trace", func(t *testing.T) {
+		type A struct{ l di.Lazy[int] }
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[A](func(l di.Lazy[int]) A { return A{l} }))
+
+		_, err := di.MustResolveIn[A](s).l.Get()
+		assert.ErrorIs(t, err, di.ErrNotRegistered)
+		assert.ErrorContains(t, err, "deferred: di_test.A -> di.Lazy[int]")
+
+		s.MustDestroy()
+	})
+
+	t.Run("Zero", func(t *testing.T) {
+		_, err := di.Lazy[int]{}.Get()
+		assert.ErrorIs(t, err, di.ErrNil)
+	})
+}
+
+func TestProvider(t *testing.T) {
+	t.Run("Minimal", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[int](rotate(1, 2, 3)))
+
+		provider := di.MustResolveIn[di.Provider[int]](s)
+
+		for _, expected := range []int{1, 2, 3} {
+			value, err := provider.Get()
+			assert.Equal(t, expected, value)
+			assert.NoError(t, err)
+		}
+
+		s.MustDestroy()
+	})
+
+	t.Run("Named", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](5).Named("five"))
+
+		value, err := di.MustResolveNamedIn[di.Provider[int]](s, "five").Get()
+		assert.Equal(t, 5, value)
+		assert.NoError(t, err)
+
+		s.MustDestroy()
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Singleton[*providerA](func(b di.Provider[*providerB]) *providerA { return &providerA{b} }),
+			di.Factory[*providerB](func(a *providerA) *providerB { return &providerB{a} }))
+
+		a := di.MustResolveIn[*providerA](s)
+		b1, _ := a.b.Get()
+		b2, _ := a.b.Get()
+		assert.Same(t, a, b1.a)
+		assert.NotSame(t, b1, b2)
+
+		s.MustDestroy()
+	})
+
+	t.Run("CycleDuringCreation", func(t *testing.T) {
+		type (
+			A struct{}
+			B struct{}
+		)
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[A](func(b di.Provider[B]) (A, error) { _, err := b.Get(); return A{}, err }),
+			di.Factory[B](func(A) B { return B{} }))
+
+		_, err := di.ResolveIn[A](s)
+		assert.ErrorIs(t, err, di.ErrDeferred)
+		assert.ErrorIs(t, err, di.ErrCycle)
+
+		s.MustDestroy()
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		errs := rotate(errors.New("whoops"), errors.New("floops"))
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[int](func() (int, error) { return 0, errs() }))
+
+		provider := di.MustResolveIn[di.Provider[int]](s)
+
+		_, err := provider.Get()
+		assert.ErrorIs(t, err, di.ErrDeferred)
+		assert.ErrorContains(t, err, "whoops")
+
+		_, err = provider.Get()
+		assert.ErrorContains(t, err, "floops")
+
+		s.MustDestroy()
+	})
+
+	t.Run("Zero", func(t *testing.T) {
+		_, err := di.Provider[int]{}.Get()
+		assert.ErrorIs(t, err, di.ErrNil)
+	})
+}
@@ -0,0 +1,204 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+)
+
+// validateHook validates a function called with a value of type v (e.g., a destroy function).
+func validateHook(name string, v reflect.Type, hook reflect.Value) error {
+	if !hook.IsValid() {
+		return nil
+	}
+	if hook.Kind() != reflect.Func {
+		return newErrNotFunc(name, hook)
+	}
+	if hook.IsNil() {
+		return nil
+	}
+
+	h := hook.Type()
+
+	if (h.NumIn() != 1 && (h.NumIn() != 2 || h.In(0) != reflect.TypeFor[context.Context]())) ||
+		(h.NumOut() != 0 &&
+			(h.NumOut() != 1 || h.Out(0) != reflect.TypeFor[error]())) {
+		return newErrInvalidFunc(name, hook)
+	}
+
+	v0 := h.In(h.NumIn() - 1)
+
+	if !v.AssignableTo(v0) {
+		return newErrNotAssignable(v, v0)
+	}
+
+	return nil
+}
+
+func hookArgs(ctx context.Context, hook reflect.Value, value reflect.Value) []reflect.Value {
+	if hook.Type().NumIn() == 2 {
+		return []reflect.Value{reflect.ValueOf(&ctx).Elem(), value}
+	}
+	return []reflect.Value{value}
+}
+
+func (s *Scope) callHook(hook reflect.Value, args []reflect.Value) error {
+	out, err := s.call(hook, args)
+	if err == nil && 0 < len(out) {
+		err, _ = out[0].Interface().(error)
+	}
+	return err
+}
+
+type hook struct {
+	node        *node
+	value       reflect.Value
+	start, stop reflect.Value
+	source      Source
+}
+
+func (h hook) run(ctx context.Context, s *Scope, f reflect.Value) error {
+	if !f.IsValid() || f.IsNil() {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.callHook(f, hookArgs(ctx, f, h.value))
+}
+
+// withoutCancel returns a context which is not canceled along with ctx, but keeps its deadline,
+// such that cleanup may continue after ctx is canceled, but is still bounded.
+func withoutCancel(ctx context.Context) (context.Context, context.CancelFunc) {
+	cleanup := context.WithoutCancel(ctx)
+	if deadline, ok := ctx.Deadline(); ok {
+		return context.WithDeadline(cleanup, deadline)
+	}
+	return cleanup, func() {}
+}
+
+// registerEager records a node to be resolved by [Scope.Init].
+func (s *Scope) registerEager(n *node) {
+	s.lifecycleLock.Lock()
+	s.eager = append(s.eager, n)
+	s.lifecycleLock.Unlock()
+}
+
+// registerStarter records a node to be resolved by [Scope.Start].
+func (s *Scope) registerStarter(n *node) {
+	s.lifecycleLock.Lock()
+	s.starters = append(s.starters, n)
+	s.lifecycleLock.Unlock()
+}
+
+func (s *Scope) registerHook(n *node, value reflect.Value, start, stop reflect.Value, source Source) {
+	if (!start.IsValid() || start.IsNil()) && (!stop.IsValid() || stop.IsNil()) {
+		return
+	}
+
+	s.lifecycleLock.Lock()
+	s.hooks = append(s.hooks, hook{n, value, start, stop, source})
+	s.lifecycleLock.Unlock()
+}
+
+// Start runs the start functions of the values registered within the scope
+// (e.g., with [SingletonBuilder.OnStart]), and returns [ErrStart] for any errors encountered.
+//   - Values with start or stop functions are created first, if not yet created.
+//     Registrations which have since been replaced (see [Replace]) are neither created nor started.
+//     [ErrResolve] is returned if any cannot be created, and no start functions are run.
+//   - Start functions are run in dependency order (i.e., the order in which the values were created),
+//     and are only run once, even if Start is called again.
+//   - The context is passed to any start function of the form func(context.Context, T).
+//     Once the context is done, no further start functions are run.
+//   - If any start function fails, all values already started are stopped (see [Scope.Stop]),
+//     even if the context is canceled, but not beyond its deadline.
+//   - Start and [Scope.Stop] do not run concurrently, each waiting for any other to finish.
+func (s *Scope) Start(ctx context.Context) error {
+	s.startLock.Lock()
+	defer s.startLock.Unlock()
+
+	s.lifecycleLock.Lock()
+	starters := slices.Clone(s.starters)
+	s.lifecycleLock.Unlock()
+
+	// registrations which have since been replaced are neither created nor started
+	starters = slices.DeleteFunc(starters, func(n *node) bool { return !s.active(n) })
+
+	errs := make([]error, len(starters))
+	for i, n := range starters {
+		errs[i] = s.resolveNode(ctx, n)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	for {
+		s.lifecycleLock.Lock()
+		for s.started < len(s.hooks) && !s.active(s.hooks[s.started].node) {
+			s.hooks = slices.Delete(s.hooks, s.started, s.started+1)
+		}
+		if s.started == len(s.hooks) {
+			s.lifecycleLock.Unlock()
+			return nil
+		}
+		h := s.hooks[s.started]
+		s.lifecycleLock.Unlock()
+
+		if err := h.run(ctx, s, h.start); err != nil {
+			stopCtx, cancel := withoutCancel(ctx)
+			defer cancel()
+			return errors.Join(newErrStart(s, h, err), s.stop(stopCtx))
+		}
+
+		s.lifecycleLock.Lock()
+		s.started++
+		s.lifecycleLock.Unlock()
+	}
+}
+
+// MustStart is like [Scope.Start] but panics on error.
+func (s *Scope) MustStart(ctx context.Context) {
+	if err := s.Start(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Stop runs the stop functions of the values started within the scope
+// (e.g., with [SingletonBuilder.OnStop]), and returns [ErrStop] for any errors encountered.
+//   - Stop functions are run in the reverse order of start functions,
+//     and only for values which have been started (see [Scope.Start]).
+//   - The context is passed to any stop function of the form func(context.Context, T).
+//     Once the context is done, stop functions are no longer run, and each is reported with the error of the context.
+//   - Stopped values may be started again.
+func (s *Scope) Stop(ctx context.Context) error {
+	s.startLock.Lock()
+	defer s.startLock.Unlock()
+
+	return s.stop(ctx)
+}
+
+func (s *Scope) stop(ctx context.Context) error {
+	s.lifecycleLock.Lock()
+	started := slices.Clone(s.hooks[:s.started])
+	s.started = 0
+	s.lifecycleLock.Unlock()
+
+	errs := make([]error, len(started))
+
+	for i, h := range slices.Backward(started) {
+		if err := h.run(ctx, s, h.stop); err != nil {
+			errs[i] = newErrStop(s, h, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// MustStop is like [Scope.Stop] but panics on error.
+func (s *Scope) MustStop(ctx context.Context) {
+	if err := s.Stop(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,279 @@
+package di_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/michaeljpetter/di"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLifecycle(t *testing.T) {
+	type (
+		A struct{}
+		B struct{}
+		C struct{}
+	)
+
+	t.Run("StartStop", func(t *testing.T) {
+		var events []string
+		hook := func(event string) func(any) {
+			return func(v any) { events = append(events, fmt.Sprintf("%s %T", event, v)) }
+		}
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Singleton[A](func(B, C) A { events = append(events, "create A"); return A{} }).
+				OnStart(hook("start")).
+				OnStop(hook("stop")),
+			di.Singleton[B](func(C) B { events = append(events, "create B"); return B{} }).
+				OnStart(func(ctx context.Context, v B) error { hook("start")(v); return ctx.Err() }),
+			di.Instance[C](C{}).
+				OnStart(hook("start")).
+				OnStop(func(ctx context.Context, v C) { hook("stop")(v) }))
+
+		assert.NoError(t, s.Start(context.Background()))
+		assert.Equal(t, []string{
+			"create B",
+			"create A",
+			"start di_test.C",
+			"start di_test.B",
+			"start di_test.A",
+		}, events)
+
+		events = nil
+		assert.NoError(t, s.Start(context.Background()))
+		assert.Empty(t, events)
+
+		assert.NoError(t, s.Stop(context.Background()))
+		assert.Equal(t, []string{
+			"stop di_test.A",
+			"stop di_test.C",
+		}, events)
+
+		events = nil
+		assert.NoError(t, s.Stop(context.Background()))
+		assert.Empty(t, events)
+
+		s.MustStart(context.Background())
+		s.MustStop(context.Background())
+		assert.Equal(t, []string{
+			"start di_test.C",
+			"start di_test.B",
+			"start di_test.A",
+			"stop di_test.A",
+			"stop di_test.C",
+		}, events)
+	})
+
+	t.Run("StartError", func(t *testing.T) {
+		var events []string
+		hook := func(event string) func(any) {
+			return func(v any) { events = append(events, fmt.Sprintf("%s %T", event, v)) }
+		}
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[A](A{}).
+				OnStart(hook("start")).
+				OnStop(hook("stop")),
+			di.Instance[B](B{}).
+				OnStart(func(B) error { return errors.New("failed") }).
+				OnStop(hook("stop")),
+			di.Instance[C](C{}).
+				OnStart(hook("start")).
+				OnStop(hook("stop")))
+
+		err := s.Start(context.Background())
+		assert.ErrorIs(t, err, di.ErrStart)
+		assert.ErrorContains(t, err, "start: test -> [di_test.B] {} at ")
+		assert.ErrorContains(t, err, "failed")
+
+		var e *di.HookError
+		if assert.ErrorAs(t, err, &e) {
+			assert.Same(t, s, e.Scope)
+			assert.Equal(t, di.ErrStart, e.Hook)
+			assert.Equal(t, B{}, e.Value)
+		}
+
+		assert.Equal(t, []string{
+			"start di_test.A",
+			"stop di_test.A",
+		}, events)
+
+		assert.Panics(t, func() { s.MustStart(context.Background()) })
+	})
+
+	t.Run("StartResolveError", func(t *testing.T) {
+		var events []string
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[A](A{}).
+				OnStart(func(A) { events = append(events, "start A") }),
+			di.Singleton[B](func(C) B { return B{} }).
+				OnStart(func(B) { events = append(events, "start B") }))
+
+		err := s.Start(context.Background())
+		assert.ErrorIs(t, err, di.ErrResolve)
+		assert.ErrorIs(t, err, di.ErrNotRegistered)
+		assert.NotErrorIs(t, err, di.ErrStart)
+		assert.Empty(t, events)
+	})
+
+	t.Run("Replaced", func(t *testing.T) {
+		var events []string
+		hook := func(event string) func(any) {
+			return func(v any) { events = append(events, fmt.Sprint(event, " ", v)) }
+		}
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Singleton[string](func() string { return "old" }).OnStart(hook("start")),
+			di.Singleton[string](func() string { return "new" }).OnStart(hook("start")),
+			di.Instance[int](1).OnStart(hook("start")).OnStop(hook("stop")),
+			di.Instance[int](2).OnStart(hook("start")).OnStop(hook("stop")),
+			di.Instance[float64](1.5).MapKey("a").OnStart(hook("start")),
+			di.Instance[float64](2.5).MapKey("a").OnStart(hook("start")),
+			di.Instance[float64](3.5).MapKey("b").OnStart(hook("start")))
+
+		assert.NoError(t, s.Start(context.Background()))
+		assert.NoError(t, s.Stop(context.Background()))
+		assert.Equal(t, []string{
+			"start 2",
+			"start 2.5",
+			"start 3.5",
+			"start new",
+			"stop 2",
+		}, events)
+	})
+
+	t.Run("StartContextDone", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		var events []string
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[A](A{}).
+				OnStart(func(A) { events = append(events, "start A"); cancel() }).
+				OnStop(func(ctx context.Context, _ A) error { events = append(events, "stop A"); return ctx.Err() }),
+			di.Instance[B](B{}).
+				OnStart(func(B) { events = append(events, "start B") }))
+
+		err := s.Start(ctx)
+		assert.ErrorIs(t, err, di.ErrStart)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.NotErrorIs(t, err, di.ErrStop)
+		assert.Equal(t, []string{"start A", "stop A"}, events)
+	})
+
+	t.Run("StartErrorDeadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[A](A{}).
+				OnStop(func(ctx context.Context, _ A) error { <-ctx.Done(); return ctx.Err() }),
+			di.Instance[B](B{}).
+				OnStart(func(B) error { return errors.New("failed") }))
+
+		done := make(chan error, 1)
+		go func() { done <- s.Start(ctx) }()
+
+		select {
+		case err := <-done:
+			assert.ErrorIs(t, err, di.ErrStart)
+			assert.ErrorIs(t, err, di.ErrStop)
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+		case <-time.After(time.Second):
+			t.Fatal("start did not return by the deadline")
+		}
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		var (
+			lock    sync.Mutex
+			running = make(map[any]bool)
+			errs    []error
+		)
+		hook := func(start bool) func(any) {
+			return func(v any) {
+				lock.Lock()
+				if running[v] == start {
+					errs = append(errs, fmt.Errorf("%T: running is already %v", v, start))
+				}
+				running[v] = start
+				lock.Unlock()
+				time.Sleep(time.Millisecond)
+			}
+		}
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[A](A{}).OnStart(hook(true)).OnStop(hook(false)),
+			di.Instance[B](B{}).OnStart(hook(true)).OnStop(hook(false)),
+			di.Singleton[C](func() C { return C{} }).OnStart(hook(true)).OnStop(hook(false)))
+
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 10 {
+					assert.NoError(t, s.Start(context.Background()))
+					assert.NoError(t, s.Stop(context.Background()))
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Empty(t, errs)
+		assert.Equal(t, map[any]bool{A{}: false, B{}: false, C{}: false}, running)
+	})
+
+	t.Run("StopError", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		var events []string
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[A](A{}).
+				OnStop(func(A) { events = append(events, "stop A") }),
+			di.Instance[B](B{}).
+				OnStop(func(B) error { cancel(); return errors.New("failed") }),
+			di.Instance[C](C{}).
+				OnStop(func(C) { events = append(events, "stop C") }))
+
+		s.MustStart(ctx)
+
+		err := s.Stop(ctx)
+		assert.ErrorIs(t, err, di.ErrStop)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorContains(t, err, "stop: test -> [di_test.B] {} at ")
+		assert.ErrorContains(t, err, "stop: test -> [di_test.A] {} at ")
+		assert.Equal(t, []string{"stop C"}, events)
+
+		assert.NotPanics(t, func() { s.MustStop(ctx) })
+	})
+
+	t.Run("InvalidHook", func(t *testing.T) {
+		s := di.NewScope("test")
+
+		err := s.Register(
+			di.Instance[A](A{}).OnStart(func(B) {}),
+			di.Singleton[B](func() B { return B{} }).OnStop(123),
+			di.Singleton[C](func() C { return C{} }).OnStart(func(C, C) {}))
+
+		assert.ErrorIs(t, err, di.ErrNotAssignable)
+		assert.ErrorIs(t, err, di.ErrNotFunc)
+		assert.ErrorContains(t, err, "must be a function: stop")
+		assert.ErrorIs(t, err, di.ErrInvalidFunc)
+		assert.ErrorContains(t, err, "invalid function: start")
+	})
+}
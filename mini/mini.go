@@ -17,18 +17,48 @@ func Register(registrables ...di.Registrable) {
 	mini.MustRegister(registrables...)
 }
 
+// Validate validates the implicit scope.
+// See [di.Scope.Validate].
+func Validate() {
+	mini.MustValidate()
+}
+
+// Graph returns the dependency graph of the implicit scope.
+// See [di.Scope.Graph].
+func Graph() *di.Graph {
+	return mini.Graph()
+}
+
+// Init creates the eager singletons of the implicit scope.
+// See [di.Scope.Init].
+func Init() {
+	mini.MustInit()
+}
+
 // Resolve resolves a value in the implicit scope.
 // See [di.ResolveIn].
 func Resolve[R any]() R {
 	return di.MustResolveIn[R](mini)
 }
 
+// ResolveNamed resolves a named value in the implicit scope.
+// See [di.ResolveNamedIn].
+func ResolveNamed[R any](name string) R {
+	return di.MustResolveNamedIn[R](mini, name)
+}
+
 // Invoke calls a function in the implicit scope.
 // See [di.InvokeIn].
 func Invoke(function any) []any {
 	return di.MustInvokeIn(mini, function)
 }
 
+// Inject sets the fields of a struct in the implicit scope.
+// See [di.InjectIn].
+func Inject(target any) {
+	di.MustInjectIn(mini, target)
+}
+
 // Destroy destroys the implicit scope.
 // See [di.Scope.Destroy].
 func Destroy() {
@@ -50,7 +80,27 @@ func Singleton[R any](create any) di.SingletonBuilder {
 	return di.Singleton[R](create)
 }
 
+// See [di.Scoped].
+func Scoped[R any](create any) di.ScopedBuilder {
+	return di.Scoped[R](create)
+}
+
+// See [di.Provide].
+func Provide(create any) di.ProvideBuilder {
+	return di.Provide(create)
+}
+
 // See [di.Alias].
 func Alias[R, Of any]() di.AliasBuilder {
 	return di.Alias[R, Of]()
 }
+
+// See [di.Decorate].
+func Decorate[R any](decorate any) di.DecorateBuilder {
+	return di.Decorate[R](decorate)
+}
+
+// See [di.Module].
+func Module(name string, registrables ...di.Registrable) di.Registrable {
+	return di.Module(name, registrables...)
+}
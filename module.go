@@ -0,0 +1,42 @@
+package di
+
+// Module defines a named, reusable bundle of registrations,
+// such as the wiring exported by a package.
+// Any registrables may be bundled, including other modules.
+//
+// Module registers each of its registrables when it is registered.
+//   - Errors are reported with the path of modules in which they occurred (e.g., "app/storage/...").
+//   - A module registered more than once within the same scope is registered only the first time.
+//     Modules are identified by the value returned from Module, not by name.
+func Module(name string, registrables ...Registrable) Registrable {
+	return &moduleBuilder{
+		name:         name,
+		registrables: registrables,
+	}
+}
+
+type moduleBuilder struct {
+	name         string
+	registrables []Registrable
+}
+
+func (b *moduleBuilder) String() string {
+	return b.name
+}
+
+func (b *moduleBuilder) registerIn(s *Scope, path string) error {
+	s.providersLock.Lock()
+	registered := s.modules[b]
+	s.modules[b] = true
+	s.providersLock.Unlock()
+
+	if registered {
+		return nil
+	}
+
+	return s.registerIn(path+b.name+"/", b.registrables)
+}
+
+func (b *moduleBuilder) register(s *Scope) error {
+	return b.registerIn(s, "")
+}
@@ -0,0 +1,95 @@
+package di_test
+
+import (
+	"github.com/michaeljpetter/di"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestModule(t *testing.T) {
+	t.Run("Minimal", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Module("mod",
+				di.Instance[int](1),
+				di.Factory[string](func() string { return "two" })))
+
+		assert.Equal(t, 1, di.MustResolveIn[int](s))
+		assert.Equal(t, "two", di.MustResolveIn[string](s))
+
+		s.MustDestroy()
+	})
+
+	t.Run("Nested", func(t *testing.T) {
+		inner := di.Module("inner",
+			di.Instance[int](1))
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Module("outer",
+				inner,
+				di.Instance[string]("two")))
+
+		assert.Equal(t, 1, di.MustResolveIn[int](s))
+		assert.Equal(t, "two", di.MustResolveIn[string](s))
+
+		s.MustDestroy()
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		s := di.NewScope("test")
+
+		err := s.Register(
+			di.Module("outer",
+				di.Instance[int](1),
+				di.Module("inner",
+					di.Instance[int]("nope"),
+					di.Instance[string](nil)),
+				di.Instance[float64]("nope")))
+
+		assert.ErrorIs(t, err, di.ErrRegister)
+		assert.ErrorIs(t, err, di.ErrNotConvertible)
+		assert.ErrorContains(t, err, "register: test <- outer/inner/Instance[int]")
+		assert.ErrorContains(t, err, "register: test <- outer/inner/Instance[string]")
+		assert.ErrorContains(t, err, "register: test <- outer/Instance[float64]")
+
+		assert.Equal(t, 1, di.MustResolveIn[int](s))
+
+		s.MustDestroy()
+	})
+
+	t.Run("Deduplicated", func(t *testing.T) {
+		calls := 0
+		shared := di.Module("shared",
+			di.Factory[int](func() int { calls++; return calls }).Group())
+
+		s := di.NewScope("test", di.OnDuplicate(di.Reject))
+		s.MustRegister(
+			shared,
+			di.Module("a", shared),
+			di.Module("b", shared))
+		s.MustRegister(
+			shared)
+
+		assert.Equal(t, []int{1}, di.MustResolveIn[[]int](s))
+
+		c := s.NewChild("child")
+		c.MustRegister(
+			shared)
+
+		assert.Equal(t, []int{2, 3}, di.MustResolveIn[[]int](c))
+
+		s.MustDestroy()
+	})
+
+	t.Run("SameName", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Module("mod", di.Instance[int](1).Group()),
+			di.Module("mod", di.Instance[int](2).Group()))
+
+		assert.Equal(t, []int{1, 2}, di.MustResolveIn[[]int](s))
+
+		s.MustDestroy()
+	})
+}
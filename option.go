@@ -0,0 +1,64 @@
+package di
+
+import (
+	"time"
+)
+
+// ScopeOption configures a [Scope].
+// See [NewScope] and [Scope.NewChild].
+type ScopeOption func(*Scope)
+
+// DuplicatePolicy defines how a [Scope] handles a registration for a type
+// (and name) that is already registered within the scope.
+type DuplicatePolicy int
+
+const (
+	// Replace replaces the existing registration with the new registration.
+	Replace DuplicatePolicy = iota
+	// Reject rejects the new registration, and returns [ErrDuplicate].
+	Reject
+	// Keep keeps the existing registration, and ignores the new registration.
+	Keep
+)
+
+// RecoverPanics configures a scope to recover panics in the functions it calls,
+// and return them as [ErrPanic] (wrapped in the usual [ErrResolve] or [ErrDestroy]).
+// By default, panics are not recovered.
+//   - Create, decorate, and invoked functions are recovered (see [InvokeIn]).
+//   - Destroy functions are recovered.
+//
+// Regardless of this option, a value whose creation panicked is never cached:
+// a later resolution of the same [Singleton] returns [ErrPanic] rather than a zero value.
+func RecoverPanics() ScopeOption {
+	return func(s *Scope) {
+		s.recoverPanics = true
+	}
+}
+
+// DestroyTimeout configures a limit on the time given to each destroy function of a scope
+// (see [Scope.DestroyContext]). By default, there is no limit.
+func DestroyTimeout(timeout time.Duration) ScopeOption {
+	return func(s *Scope) {
+		s.destroyTimeout = timeout
+	}
+}
+
+// InitConcurrency configures the number of values which may be created concurrently
+// by [Scope.Init]. The default limit is runtime.GOMAXPROCS(0) at the time the scope is created.
+//   - A limit of 1 creates values one at a time, in dependency order.
+//   - A limit of 0 (or less) allows any number of values to be created concurrently.
+func InitConcurrency(limit int) ScopeOption {
+	return func(s *Scope) {
+		s.initConcurrency = limit
+	}
+}
+
+// OnDuplicate configures the [DuplicatePolicy] of a scope.
+// The default policy is [Replace].
+//   - Groups are unaffected, as their registrations never duplicate one another.
+//   - Map entries are duplicates when registered with the same key.
+func OnDuplicate(policy DuplicatePolicy) ScopeOption {
+	return func(s *Scope) {
+		s.onDuplicate = policy
+	}
+}
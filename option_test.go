@@ -0,0 +1,199 @@
+package di_test
+
+import (
+	"github.com/michaeljpetter/di"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestOnDuplicate(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1),
+			di.Instance[int](2))
+
+		assert.Equal(t, 2, di.MustResolveIn[int](s))
+
+		s.MustDestroy()
+	})
+
+	t.Run("Replace", func(t *testing.T) {
+		var destroyed []any
+		destroy := func(v any) { destroyed = append(destroyed, v) }
+
+		s := di.NewScope("test", di.OnDuplicate(di.Replace))
+		s.MustRegister(
+			di.Instance[int](1).Destroy(destroy),
+			di.Instance[int](2).Destroy(destroy),
+			di.Instance[int](3).MapKey("a"),
+			di.Instance[int](4).MapKey("a"))
+
+		assert.Equal(t, 2, di.MustResolveIn[int](s))
+		assert.Equal(t, map[string]int{"a": 4}, di.MustResolveIn[map[string]int](s))
+
+		s.MustDestroy()
+
+		assert.Equal(t, []any{2, 1}, destroyed)
+	})
+
+	t.Run("Reject", func(t *testing.T) {
+		var destroyed []any
+		destroy := func(v any) { destroyed = append(destroyed, v) }
+
+		s := di.NewScope("test", di.OnDuplicate(di.Reject))
+		s.MustRegister(
+			di.Instance[int](1).Destroy(destroy),
+			di.Instance[int](3).MapKey("a"),
+			di.Instance[int](5).Group())
+
+		err := s.Register(
+			di.Instance[int](2).Destroy(destroy))
+		assert.ErrorIs(t, err, di.ErrRegister)
+		assert.ErrorIs(t, err, di.ErrDuplicate)
+		assert.ErrorContains(t, err, "duplicate registration: int")
+
+		err = s.Register(
+			di.Factory[int](rotate(4)).MapKey("a"))
+		assert.ErrorIs(t, err, di.ErrDuplicate)
+		assert.ErrorContains(t, err, `duplicate registration: int (key "a")`)
+
+		assert.NoError(t, s.Register(
+			di.Instance[int](2).Named("two"),
+			di.Instance[int](4).MapKey("b"),
+			di.Instance[int](6).Group()))
+
+		err = s.Register(
+			di.Instance[[]int](nil))
+		assert.ErrorIs(t, err, di.ErrDuplicate)
+
+		assert.Equal(t, 1, di.MustResolveIn[int](s))
+		assert.Equal(t, map[string]int{"a": 3, "b": 4}, di.MustResolveIn[map[string]int](s))
+		assert.Equal(t, []int{5, 6}, di.MustResolveIn[[]int](s))
+
+		s.MustDestroy()
+
+		assert.Equal(t, []any{1}, destroyed)
+	})
+
+	t.Run("Keep", func(t *testing.T) {
+		var destroyed []any
+		destroy := func(v any) { destroyed = append(destroyed, v) }
+
+		s := di.NewScope("test", di.OnDuplicate(di.Keep))
+		s.MustRegister(
+			di.Instance[int](1).Destroy(destroy),
+			di.Instance[int](2).Destroy(destroy),
+			di.Instance[int](3).MapKey("a"),
+			di.Instance[int](4).MapKey("a"))
+
+		assert.Equal(t, 1, di.MustResolveIn[int](s))
+		assert.Equal(t, map[string]int{"a": 3}, di.MustResolveIn[map[string]int](s))
+
+		s.MustDestroy()
+
+		assert.Equal(t, []any{1}, destroyed)
+	})
+
+	t.Run("Child", func(t *testing.T) {
+		s := di.NewScope("test", di.OnDuplicate(di.Reject))
+		s.MustRegister(
+			di.Instance[int](1))
+
+		c := s.NewChild("child")
+		c.MustRegister(
+			di.Instance[int](2))
+
+		assert.ErrorIs(t, c.Register(di.Instance[int](3)), di.ErrDuplicate)
+
+		r := s.NewChild("replace", di.OnDuplicate(di.Replace))
+		r.MustRegister(
+			di.Instance[int](2),
+			di.Instance[int](3))
+
+		assert.Equal(t, 2, di.MustResolveIn[int](c))
+		assert.Equal(t, 3, di.MustResolveIn[int](r))
+
+		s.MustDestroy()
+	})
+}
+
+func TestRecoverPanics(t *testing.T) {
+	type A struct{}
+
+	t.Run("Default", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[int](func() int { panic("boom") }),
+			di.Singleton[A](func() A { panic("boom") }))
+
+		assert.PanicsWithValue(t, "boom", func() { di.ResolveIn[int](s) })
+		assert.PanicsWithValue(t, "boom", func() { di.ResolveIn[A](s) })
+
+		_, err := di.ResolveIn[A](s)
+		assert.ErrorIs(t, err, di.ErrResolve)
+		assert.ErrorIs(t, err, di.ErrPanic)
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		s := di.NewScope("test", di.RecoverPanics())
+		s.MustRegister(
+			di.Factory[int](func() int { panic("boom") }),
+			di.Singleton[A](func(int) A { return A{} }))
+
+		for range 2 {
+			_, err := di.ResolveIn[A](s)
+			assert.ErrorIs(t, err, di.ErrResolve)
+			assert.ErrorIs(t, err, di.ErrInvoke)
+			assert.ErrorIs(t, err, di.ErrPanic)
+			assert.ErrorContains(t, err, "di: panic: boom")
+
+			var e *di.PanicError
+			if assert.ErrorAs(t, err, &e) {
+				assert.Equal(t, "boom", e.Value)
+				assert.Contains(t, string(e.Stack), "option_test.go")
+			}
+		}
+	})
+
+	t.Run("Decorate", func(t *testing.T) {
+		s := di.NewScope("test", di.RecoverPanics())
+		s.MustRegister(
+			di.Instance[int](1),
+			di.Decorate[int](func(int) int { panic("boom") }))
+
+		_, err := di.ResolveIn[int](s)
+		assert.ErrorIs(t, err, di.ErrDecorate)
+		assert.ErrorIs(t, err, di.ErrPanic)
+	})
+
+	t.Run("Invoke", func(t *testing.T) {
+		s := di.NewScope("test").NewChild("child", di.RecoverPanics())
+
+		_, err := di.InvokeIn(s, func() { panic("boom") })
+		assert.ErrorIs(t, err, di.ErrInvoke)
+		assert.ErrorIs(t, err, di.ErrPanic)
+
+		c := s.NewChild("grandchild")
+
+		_, err = di.InvokeIn(c, func() { panic("boom") })
+		assert.ErrorIs(t, err, di.ErrPanic)
+	})
+
+	t.Run("Destroy", func(t *testing.T) {
+		var destroyed []any
+		destroy := func(v int) { destroyed = append(destroyed, v) }
+
+		s := di.NewScope("test", di.RecoverPanics())
+		s.MustRegister(
+			di.Instance[int](1).Destroy(destroy),
+			di.Instance[int](2).Destroy(func(int) { panic("boom") }).Named("two"),
+			di.Instance[int](3).Destroy(destroy).Named("three"))
+
+		err := s.Destroy()
+		assert.ErrorIs(t, err, di.ErrDestroy)
+		assert.ErrorIs(t, err, di.ErrPanic)
+		assert.ErrorContains(t, err, "destroy: test -> [int] 2")
+		assert.Equal(t, []any{3, 1}, destroyed)
+	})
+}
@@ -0,0 +1,52 @@
+package di
+
+import (
+	"context"
+	"reflect"
+)
+
+// Optional is a dependency on type T which need not be registered.
+// It may be used in place of T anywhere a dependency is resolved,
+// such as the parameters of a create function, or with [ResolveIn].
+//
+// Optional resolves to an empty value if T is not registered,
+// rather than failing with [ErrNotRegistered].
+//   - Any other errors encountered when resolving T are returned as usual.
+//   - The name of a named dependency applies to T.
+type Optional[T any] struct {
+	value T
+	ok    bool
+}
+
+// Get returns the resolved value of T, and whether T was registered.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.ok
+}
+
+// OrElse returns the resolved value of T if T was registered, or the given value otherwise.
+func (o Optional[T]) OrElse(value T) T {
+	if o.ok {
+		return o.value
+	}
+	return value
+}
+
+func (Optional[T]) resolveIn(ctx context.Context, s *Scope, k key, trace trace) (reflect.Value, error) {
+	t := key{reflect.TypeFor[T](), k.name}
+
+	if n, _ := s.lookup(t); n == nil && t != contextKey {
+		return reflect.ValueOf(Optional[T]{}), nil
+	}
+
+	value, err := s.resolve(ctx, t, trace)
+	if err != nil {
+		return reflect.Zero(k.t), err
+	}
+
+	iface, _ := value.Interface().(T)
+	return reflect.ValueOf(Optional[T]{iface, true}), nil
+}
+
+func (Optional[T]) dependency(k key) dependency {
+	return dependency{key: key{reflect.TypeFor[T](), k.name}, optional: true}
+}
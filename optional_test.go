@@ -0,0 +1,123 @@
+package di_test
+
+import (
+	"errors"
+	"github.com/michaeljpetter/di"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestOptional(t *testing.T) {
+	type interfaceT interface{ M() }
+
+	t.Run("Registered", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](5))
+
+		value, ok := di.MustResolveIn[di.Optional[int]](s).Get()
+		assert.Equal(t, 5, value)
+		assert.True(t, ok)
+
+		s.MustDestroy()
+	})
+
+	t.Run("NotRegistered", func(t *testing.T) {
+		s := di.NewScope("test")
+
+		value, ok := di.MustResolveIn[di.Optional[int]](s).Get()
+		assert.Zero(t, value)
+		assert.False(t, ok)
+
+		s.MustDestroy()
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[interfaceT](nil))
+
+		value, ok := di.MustResolveIn[di.Optional[interfaceT]](s).Get()
+		assert.Nil(t, value)
+		assert.True(t, ok)
+
+		s.MustDestroy()
+	})
+
+	t.Run("OrElse", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](5))
+
+		assert.Equal(t, 5, di.MustResolveIn[di.Optional[int]](s).OrElse(7))
+		assert.Equal(t, "seven", di.MustResolveIn[di.Optional[string]](s).OrElse("seven"))
+
+		s.MustDestroy()
+	})
+
+	t.Run("Named", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](5).Named("five"))
+
+		assert.Equal(t, 5, di.MustResolveNamedIn[di.Optional[int]](s, "five").OrElse(0))
+		assert.Equal(t, 0, di.MustResolveIn[di.Optional[int]](s).OrElse(0))
+
+		s.MustDestroy()
+	})
+
+	t.Run("Dependent", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](5),
+			di.Factory[string](func(i di.Optional[int], f di.Optional[float64]) string {
+				if _, ok := f.Get(); ok {
+					return "float"
+				}
+				return "int"
+			}))
+
+		assert.Equal(t, "int", di.MustResolveIn[string](s))
+
+		values, err := di.InvokeIn(s, func(i di.Optional[int]) int { return i.OrElse(0) + 1 })
+		assert.Equal(t, []any{6}, values)
+		assert.NoError(t, err)
+
+		s.MustDestroy()
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[int](func() (int, error) { return 0, errors.New("whoops") }))
+
+		_, err := di.ResolveIn[di.Optional[int]](s)
+		assert.ErrorIs(t, err, di.ErrResolve)
+		assert.ErrorContains(t, err, "whoops")
+
+		s.MustDestroy()
+	})
+
+	t.Run("DependencyNotRegistered", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[int](func(float64) int { return 0 }))
+
+		_, err := di.ResolveIn[di.Optional[int]](s)
+		assert.ErrorIs(t, err, di.ErrNotRegistered)
+		assert.ErrorContains(t, err, "float64")
+
+		s.MustDestroy()
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[int](func(di.Optional[int]) int { return 0 }))
+
+		_, err := di.ResolveIn[int](s)
+		assert.ErrorIs(t, err, di.ErrCycle)
+
+		s.MustDestroy()
+	})
+}
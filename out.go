@@ -0,0 +1,290 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Out marks a result struct when embedded within it.
+// A result struct may be returned by the create function of a [Provide].
+// Rather than registering the struct itself, each of its exported fields is registered
+// as its own value, and all of the fields share the single creation of the struct.
+//
+//	type Results struct {
+//		di.Out
+//		Pool     *Pool
+//		Migrator *Migrator
+//		Check    HealthCheck `di:"group"`
+//	}
+//
+// Fields may be configured with a di tag of comma-separated options:
+//   - name=N registers the field with name N.
+//   - group registers the field as a member of the group for its type (see [FactoryBuilder.Group]).
+//   - key=K registers the field as the entry with key K in the map for its type (see [FactoryBuilder.MapKey]).
+//
+// Result structs must not have unexported fields, other than the embedded Out.
+type Out struct{}
+
+func isOut(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := range t.NumField() {
+		if f := t.Field(i); f.Anonymous && f.Type == reflect.TypeFor[Out]() {
+			return true
+		}
+	}
+
+	return false
+}
+
+type result struct {
+	index int
+	binding
+}
+
+func parseOutTag(parent reflect.Type, f reflect.StructField) (binding, error) {
+	b := binding{key: key{t: f.Type}}
+
+	tag, ok := f.Tag.Lookup("di")
+	if !ok || tag == "" {
+		return b, nil
+	}
+
+	for _, option := range strings.Split(tag, ",") {
+		name, isName := strings.CutPrefix(option, "name=")
+		mapKey, isKey := strings.CutPrefix(option, "key=")
+
+		switch {
+		case isName && name != "":
+			b.name = name
+		case isKey && !b.group:
+			b.mapped, b.mapKey = true, mapKey
+		case option == "group" && !b.mapped:
+			b.group = true
+		default:
+			return b, newErrInvalidField(parent, f)
+		}
+	}
+
+	return b, nil
+}
+
+func outFields(t reflect.Type) ([]result, error) {
+	var results []result
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+
+		if f.Anonymous && f.Type == reflect.TypeFor[Out]() {
+			continue
+		}
+		if !f.IsExported() {
+			return nil, newErrInvalidField(t, f)
+		}
+
+		b, err := parseOutTag(t, f)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, result{i, b})
+	}
+
+	return results, nil
+}
+
+func validateProvide(create reflect.Value) (reflect.Type, []result, error) {
+	if !create.IsValid() {
+		return nil, nil, newErrNil("create")
+	}
+	if create.Kind() != reflect.Func {
+		return nil, nil, newErrNotFunc("create", create)
+	}
+	if create.IsNil() {
+		return nil, nil, newErrNil("create")
+	}
+
+	c := create.Type()
+
+	if (c.NumOut() != 1 &&
+		(c.NumOut() != 2 || c.Out(1) != reflect.TypeFor[error]())) ||
+		!isOut(c.Out(0)) {
+		return nil, nil, newErrInvalidFunc("create", create)
+	}
+
+	v := c.Out(0)
+
+	results, err := outFields(v)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := params(c, 0, nil); err != nil {
+		return nil, nil, err
+	}
+
+	return v, results, nil
+}
+
+// IsValidProvide checks whether a create function is valid for a [Provide].
+// This check is performed internally when registering with [Provide],
+// thus this method does not typically need to be called explicitly.
+//
+// In order for create to be valid, it must be:
+//   - a non-nil function returning (T) or (T, error), where T is a valid result struct (see [Out])
+//
+// There are no restrictions on its input parameters. They will be resolved as dependencies
+// when create is called to produce a value. Any parameter structs must be valid (see [In]).
+func IsValidProvide(create any) bool {
+	_, _, err := validateProvide(reflect.ValueOf(create))
+	return err == nil
+}
+
+func provide(s *Scope, create reflect.Value, names []string, destroy reflect.Value, source Source) error {
+	v, results, err := validateProvide(create)
+	if err != nil {
+		return err
+	}
+
+	if err = validateNames("create", create, 0, names); err != nil {
+		return err
+	}
+
+	if err = validateDestroy(v, destroy); err != nil {
+		return err
+	}
+
+	k := key{t: v}
+
+	var c cached
+
+	shared := func(ctx context.Context, trace trace) []reflect.Value {
+		if cycle := slices.Index(trace, k); 0 <= cycle {
+			return []reflect.Value{reflect.Zero(v), reflect.ValueOf(newErrCycle(append(trace[cycle:], k)))}
+		}
+
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if c.done {
+			return c.result
+		}
+
+		c.result = []reflect.Value{reflect.Zero(v), reflect.Zero(reflect.TypeFor[error]())}
+		c.done = true
+		defer cachePanic(c.result)
+
+		if o, err := s.invoke(ctx, create, names, append(trace, k)); err != nil {
+			c.result[1] = reflect.ValueOf(err)
+		} else if 1 < len(o) && !o[1].IsNil() {
+			c.result[1] = o[1]
+		} else {
+			c.result[0] = o[0]
+			s.registerDestroyer(o[0], destroy, source)
+		}
+
+		if err, _ := c.result[1].Interface().(error); aborted(ctx, err) {
+			c.done = false
+		}
+
+		return c.result
+	}
+
+	errs := make([]error, len(results))
+
+	for i, r := range results {
+		r.source = source
+		_, errs[i] = s.registerProvider(&node{
+			binding: r.binding,
+			kind:    "Provide",
+			deps:    dependencies(create.Type(), 0, names),
+			home:    s,
+			provider: reflect.MakeFunc(
+				providerOf(r.t),
+				func(args []reflect.Value) []reflect.Value {
+					ctx := args[0].Interface().(context.Context)
+					trace := args[2].Interface().(trace)
+
+					result := []reflect.Value{reflect.Zero(r.t), reflect.Zero(reflect.TypeFor[error]())}
+
+					if out := shared(ctx, trace); !out[1].IsNil() {
+						result[1] = out[1]
+					} else {
+						result[0] = out[0].Field(r.index)
+					}
+
+					return result
+				},
+			),
+		})
+	}
+
+	return errors.Join(errs...)
+}
+
+// ProvideBuilder provides configuration of a [Provide].
+type ProvideBuilder interface {
+	Registrable
+	// Source returns the location at which this provide was defined.
+	Source() Source
+	// ArgNames configures the names of the registrations resolved for the
+	// parameters of the create function, in order. An empty name resolves
+	// the unnamed registration, as do any parameters beyond the given names.
+	// Parameter structs (see [In]) are configured by their tags, and must be given an empty name.
+	ArgNames(names ...string) ProvideBuilder
+	// Destroy configures a destroy function for the result struct created by this provide.
+	// See IsValidDestroy for details.
+	Destroy(destroy any) ProvideBuilder
+}
+
+// Provide defines a one-time creator of multiple values (such as a "New" function).
+// The create function returns a result struct, each field of which is registered
+// as its own value. See [IsValidProvide] and [Out] for details.
+//
+// Provide creates the result struct the first time any of its fields is resolved,
+// and returns the same cached fields every time thereafter.
+//   - Dependencies are resolved at the time of value creation.
+//   - Dependencies are resolved from the scope in which the provide was registered.
+func Provide(create any) ProvideBuilder {
+	return &provideBuilder{
+		create: reflect.ValueOf(create),
+		source: callerSource(),
+	}
+}
+
+type provideBuilder struct {
+	names           []string
+	create, destroy reflect.Value
+	source          Source
+}
+
+func (b *provideBuilder) String() string {
+	if b.create.Kind() == reflect.Func && 0 < b.create.Type().NumOut() {
+		return fmt.Sprintf("Provide[%s] at %v", typeName(b.create.Type().Out(0)), b.source)
+	}
+	return fmt.Sprintf("Provide[%s] at %v", typeName(valueType(b.create)), b.source)
+}
+
+func (b *provideBuilder) Source() Source {
+	return b.source
+}
+
+func (b *provideBuilder) ArgNames(names ...string) ProvideBuilder {
+	b.names = names
+	return b
+}
+
+func (b *provideBuilder) Destroy(destroy any) ProvideBuilder {
+	b.destroy = reflect.ValueOf(destroy)
+	return b
+}
+
+func (b *provideBuilder) register(s *Scope) error {
+	return provide(s, b.create, b.names, b.destroy, b.source)
+}
@@ -0,0 +1,225 @@
+package di_test
+
+import (
+	"errors"
+	"github.com/michaeljpetter/di"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIsValidProvide(t *testing.T) {
+	type out struct {
+		di.Out
+		A int
+	}
+
+	noCall := func() { t.Error("should not call create function") }
+
+	t.Run("NoArgsReturns1", func(t *testing.T) {
+		assert.True(t, di.IsValidProvide(
+			func() out { noCall(); return out{} },
+		))
+	})
+
+	t.Run("ArgsReturns2", func(t *testing.T) {
+		assert.True(t, di.IsValidProvide(
+			func(string, *int) (out, error) { noCall(); return out{}, nil },
+		))
+	})
+
+	t.Run("NotOut", func(t *testing.T) {
+		assert.False(t, di.IsValidProvide(
+			func() struct{ A int } { noCall(); return struct{ A int }{} },
+		))
+	})
+
+	t.Run("OutPointer", func(t *testing.T) {
+		assert.False(t, di.IsValidProvide(
+			func() *out { noCall(); return nil },
+		))
+	})
+
+	t.Run("NoReturns", func(t *testing.T) {
+		assert.False(t, di.IsValidProvide(
+			func() { noCall() },
+		))
+	})
+
+	t.Run("SecondReturnNotError", func(t *testing.T) {
+		assert.False(t, di.IsValidProvide(
+			func() (out, string) { noCall(); return out{}, "" },
+		))
+	})
+
+	t.Run("InvalidField", func(t *testing.T) {
+		for name, create := range map[string]any{
+			"UnknownOption": func() (_ struct {
+				di.Out
+				A int `di:"optional"`
+			}) {
+				return
+			},
+			"EmptyName": func() (_ struct {
+				di.Out
+				A int `di:"name="`
+			}) {
+				return
+			},
+			"GroupAndKey": func() (_ struct {
+				di.Out
+				A int `di:"group,key=a"`
+			}) {
+				return
+			},
+			"Unexported": func() (_ struct {
+				di.Out
+				a int
+			}) {
+				return
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				assert.False(t, di.IsValidProvide(create))
+			})
+		}
+	})
+
+	t.Run("NilFunc", func(t *testing.T) {
+		assert.False(t, di.IsValidProvide(
+			(func() out)(nil),
+		))
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		assert.False(t, di.IsValidProvide(nil))
+	})
+
+	t.Run("NotFunc", func(t *testing.T) {
+		assert.False(t, di.IsValidProvide(123))
+	})
+}
+
+func TestProvide(t *testing.T) {
+	type out struct {
+		di.Out
+		Int    int
+		Two    int     `di:"name=two"`
+		Group  float64 `di:"group"`
+		Map    float64 `di:"key=a"`
+		String string  `di:"name=str,group"`
+	}
+
+	t.Run("Minimal", func(t *testing.T) {
+		calls := 0
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Provide(func() out {
+				calls++
+				return out{Int: 1, Two: 2, Group: 3.4, Map: 5.6, String: "seven"}
+			}))
+
+		for range 3 {
+			assert.Equal(t, 1, di.MustResolveIn[int](s))
+			assert.Equal(t, 2, di.MustResolveNamedIn[int](s, "two"))
+			assert.Equal(t, []float64{3.4}, di.MustResolveIn[[]float64](s))
+			assert.Equal(t, map[string]float64{"a": 5.6}, di.MustResolveIn[map[string]float64](s))
+			assert.Equal(t, []string{"seven"}, di.MustResolveNamedIn[[]string](s, "str"))
+		}
+
+		assert.Equal(t, 1, calls)
+
+		s.MustDestroy()
+	})
+
+	t.Run("Full", func(t *testing.T) {
+		var destroyed []out
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](2).Named("b"),
+			di.Provide(func(a int) out { return out{Int: a, Two: a} }).
+				ArgNames("b").
+				Destroy(func(v out) { destroyed = append(destroyed, v) }))
+
+		assert.Equal(t, 2, di.MustResolveIn[int](s))
+
+		s.MustDestroy()
+
+		assert.Equal(t, []out{{Int: 2, Two: 2}}, destroyed)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		errs := rotate(errors.New("whoops"), errors.New("floops"))
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Provide(func() (out, error) { return out{Int: 1}, errs() }))
+
+		for range 3 {
+			value, err := di.ResolveIn[int](s)
+			assert.Zero(t, value)
+			assert.ErrorIs(t, err, di.ErrResolve)
+			assert.ErrorContains(t, err, "whoops")
+		}
+
+		s.MustDestroy()
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		type cycle struct {
+			di.Out
+			A int
+			B string
+		}
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Provide(func(string) cycle { return cycle{} }))
+
+		_, err := di.ResolveIn[int](s)
+		assert.ErrorIs(t, err, di.ErrCycle)
+		assert.ErrorContains(t, err, ": di_test.cycle -> string -> di_test.cycle")
+
+		s.MustDestroy()
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		s := di.NewScope("test", di.OnDuplicate(di.Reject))
+		s.MustRegister(
+			di.Instance[int](0))
+
+		err := s.Register(
+			di.Provide(func() out { return out{Int: 1, Two: 2} }))
+		assert.ErrorIs(t, err, di.ErrDuplicate)
+		assert.ErrorContains(t, err, "register: test <- Provide[di_test.out]")
+
+		assert.Equal(t, 0, di.MustResolveIn[int](s))
+		assert.Equal(t, 2, di.MustResolveNamedIn[int](s, "two"))
+
+		s.MustDestroy()
+	})
+
+	t.Run("InvalidCreate", func(t *testing.T) {
+		s := di.NewScope("test")
+
+		err := s.Register(
+			di.Provide(func() int { return 0 }))
+
+		assert.ErrorIs(t, err, di.ErrInvalidFunc)
+
+		s.MustDestroy()
+	})
+
+	t.Run("InvalidDestroy", func(t *testing.T) {
+		s := di.NewScope("test")
+
+		err := s.Register(
+			di.Provide(func() out { return out{} }).
+				Destroy(func(int) {}))
+
+		assert.ErrorIs(t, err, di.ErrNotAssignable)
+
+		s.MustDestroy()
+	})
+}
@@ -1,34 +1,90 @@
 package di
 
 import (
+	"context"
 	"errors"
 	"reflect"
+	"runtime"
 	"slices"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Scope defines a container for registrations and resolution.
 type Scope struct {
-	name string
-
-	providers     map[reflect.Type]reflect.Value
+	name    string
+	parent  *Scope
+	options []ScopeOption
+
+	onDuplicate     DuplicatePolicy
+	recoverPanics   bool
+	destroyTimeout  time.Duration
+	initConcurrency int
+
+	providers     map[key]*node
+	members       map[key][]*node
+	modules       map[*moduleBuilder]bool
 	providersLock *sync.RWMutex
 
 	destroyers     []destroyer
 	destroyersLock *sync.RWMutex
+
+	eager         []*node
+	starters      []*node
+	hooks         []hook
+	started       int
+	lifecycleLock *sync.Mutex
+	startLock     *sync.Mutex
+
+	cache     map[any]*cached
+	cacheLock *sync.Mutex
+}
+
+type cached struct {
+	lock   sync.Mutex
+	done   bool
+	result []reflect.Value
 }
 
-// NewScope creates a new [Scope] with the given name.
-func NewScope(name string) *Scope {
-	return &Scope{
-		name: name,
+// NewScope creates a new [Scope] with the given name, configured by any given options.
+func NewScope(name string, options ...ScopeOption) *Scope {
+	s := &Scope{
+		name:    name,
+		options: options,
 
-		providers:     make(map[reflect.Type]reflect.Value),
+		initConcurrency: runtime.GOMAXPROCS(0),
+
+		providers:     make(map[key]*node),
+		members:       make(map[key][]*node),
+		modules:       make(map[*moduleBuilder]bool),
 		providersLock: new(sync.RWMutex),
 
 		destroyers:     make([]destroyer, 0),
 		destroyersLock: new(sync.RWMutex),
+
+		lifecycleLock: new(sync.Mutex),
+		startLock:     new(sync.Mutex),
+
+		cache:     make(map[any]*cached),
+		cacheLock: new(sync.Mutex),
 	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	return s
+}
+
+// NewChild creates a new [Scope] with the given name, nested within s.
+// Any type not registered in the child is resolved by falling back to s.
+//   - Values created by the child are destroyed with the child, not with s.
+//   - The child is configured by the options of s, followed by any given options.
+func (s *Scope) NewChild(name string, options ...ScopeOption) *Scope {
+	child := NewScope(name, slices.Concat(s.options, options)...)
+	child.parent = s
+	return child
 }
 
 // String returns the name of the scope.
@@ -36,74 +92,266 @@ func (s *Scope) String() string {
 	return s.name
 }
 
-func (s *Scope) registerProvider(r reflect.Type, provider reflect.Value) {
+func (s *Scope) registerProvider(n *node) (bool, error) {
+	s.providersLock.Lock()
+	defer s.providersLock.Unlock()
+
+	if n.group || n.mapped {
+		return s.registerMember(n)
+	}
+
+	return s.setProvider(n)
+}
+
+func (s *Scope) setProvider(n *node) (bool, error) {
+	if _, ok := s.providers[n.key]; ok {
+		switch s.onDuplicate {
+		case Reject:
+			return false, newErrDuplicate(n.binding)
+		case Keep:
+			return false, nil
+		}
+	}
+
+	s.providers[n.key] = n
+	return true, nil
+}
+
+// active reports whether a node registered within the scope is still resolvable,
+// rather than replaced by a later registration (see [Replace]).
+func (s *Scope) active(n *node) bool {
+	s.providersLock.RLock()
+	defer s.providersLock.RUnlock()
+
+	if n.group || n.mapped {
+		return slices.Contains(s.members[collectionKey(n.binding)], n)
+	}
+
+	for p := s.providers[n.key]; p != nil; p = p.decorated {
+		if p == n {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Scope) registerDecorator(k key, decorate func(*node) *node) error {
 	s.providersLock.Lock()
-	s.providers[r] = provider
+
+	n, ok := s.providers[k]
+	searched := []*Scope{s}
+	if !ok && s.parent != nil {
+		var parents []*Scope
+		n, parents = s.parent.lookup(k)
+		searched = append(searched, parents...)
+	}
+	if n != nil {
+		s.providers[k] = decorate(n)
+	}
+
 	s.providersLock.Unlock()
+
+	if n == nil {
+		return newErrNotRegistered(k, searched)
+	}
+	return nil
 }
 
-func (s *Scope) registerDestroyer(value reflect.Value, destroy reflect.Value) {
+func (s *Scope) registerDestroyer(value reflect.Value, destroy reflect.Value, source Source) {
 	if !destroy.IsValid() || destroy.IsNil() {
 		return
 	}
 
 	s.destroyersLock.Lock()
-	s.destroyers = append(s.destroyers, destroyer{value, destroy})
+	s.destroyers = append(s.destroyers, destroyer{value, destroy, source})
 	s.destroyersLock.Unlock()
 }
 
-func (s *Scope) resolve(r reflect.Type, trace trace) (reflect.Value, error) {
-	s.providersLock.RLock()
-	provider, ok := s.providers[r]
-	s.providersLock.RUnlock()
+func (s *Scope) cached(id any) *cached {
+	s.cacheLock.Lock()
+	defer s.cacheLock.Unlock()
 
+	c, ok := s.cache[id]
 	if !ok {
-		return reflect.Zero(r), newErrResolve(s, r, newErrNotRegistered(r))
+		c = new(cached)
+		s.cache[id] = c
+	}
+	return c
+}
+
+func (s *Scope) lookup(k key) (*node, []*Scope) {
+	var searched []*Scope
+
+	for scope := s; scope != nil; scope = scope.parent {
+		scope.providersLock.RLock()
+		n, ok := scope.providers[k]
+		scope.providersLock.RUnlock()
+
+		if ok {
+			return n, nil
+		}
+		searched = append(searched, scope)
+	}
+
+	return nil, searched
+}
+
+// resolvable is implemented by types which define their own resolution within a scope,
+// rather than being resolved from a registration (e.g., [Optional]).
+type resolvable interface {
+	resolveIn(ctx context.Context, s *Scope, k key, trace trace) (reflect.Value, error)
+	dependency(k key) dependency
+}
+
+// contextKey is resolved as the context of the resolution in progress (see [ResolveInContext]).
+var contextKey = key{t: reflect.TypeFor[context.Context]()}
+
+// creation is the context in which the parameters of a function are resolved,
+// which records whether the function has since returned (see [Lazy]).
+type creation struct {
+	context.Context
+	done atomic.Bool
+}
+
+// outerContext returns the context of the resolution in progress, without any creation.
+func outerContext(ctx context.Context) context.Context {
+	if c, ok := ctx.(*creation); ok {
+		return c.Context
 	}
+	return ctx
+}
+
+func (s *Scope) resolve(ctx context.Context, k key, trace trace) (reflect.Value, error) {
+	if k == contextKey {
+		ctx = outerContext(ctx)
+		return reflect.ValueOf(&ctx).Elem(), nil
+	}
+
+	if k.t.Implements(reflect.TypeFor[resolvable]()) {
+		return reflect.Zero(k.t).Interface().(resolvable).resolveIn(ctx, s, k, trace)
+	}
+
+	n, searched := s.lookup(k)
 
-	if cycle := slices.Index(trace, r); 0 <= cycle {
-		return reflect.Zero(r), newErrResolve(s, r, newErrCycle(append(trace[cycle:], r)))
+	if n == nil {
+		return reflect.Zero(k.t), newErrResolve(s, binding{key: k}, trace, newErrNotRegistered(k, searched))
 	}
 
-	out := provider.Call([]reflect.Value{
-		reflect.ValueOf(s), reflect.ValueOf(append(trace, r)),
+	if cycle := slices.Index(trace, k); 0 <= cycle {
+		return reflect.Zero(k.t), newErrResolve(s, n.binding, trace, newErrCycle(append(trace[cycle:], k)))
+	}
+
+	out := n.provider.Call([]reflect.Value{
+		reflect.ValueOf(&ctx).Elem(), reflect.ValueOf(s), reflect.ValueOf(append(trace, k)),
 	})
 
 	value := out[0]
 	err, _ := out[1].Interface().(error)
 
 	if err != nil {
-		err = newErrResolve(s, r, err)
+		err = newErrResolve(s, n.binding, trace, err)
 	}
 
 	return value, err
 }
 
-func (s *Scope) invoke(function reflect.Value, trace trace) ([]reflect.Value, error) {
-	f := function.Type()
-	args := make([]reflect.Value, f.NumIn())
+// resolveNode resolves the value of a node registered within the scope,
+// which need not be resolvable by its key (e.g., a group member).
+func (s *Scope) resolveNode(ctx context.Context, n *node) error {
+	out := n.provider.Call([]reflect.Value{
+		reflect.ValueOf(&ctx).Elem(), reflect.ValueOf(s), reflect.ValueOf(trace{resolutionKey(n.binding)}),
+	})
+
+	if err, _ := out[1].Interface().(error); err != nil {
+		return newErrResolve(s, n.binding, nil, err)
+	}
+	return nil
+}
+
+func (s *Scope) invoke(ctx context.Context, function reflect.Value, names []string, trace trace) ([]reflect.Value, error) {
+	return s.invokeWith(ctx, function, nil, names, trace)
+}
+
+func (s *Scope) invokeWith(ctx context.Context, function reflect.Value, given []reflect.Value, names []string, trace trace) ([]reflect.Value, error) {
+	params, err := params(function.Type(), len(given), names)
+	if err != nil {
+		return nil, newErrInvoke(s, function, err)
+	}
+
+	args := append(make([]reflect.Value, 0, len(given)+len(params)), given...)
+
+	c := &creation{Context: outerContext(ctx)}
+	defer c.done.Store(true)
+	ctx = c
 
-	for i := range f.NumIn() {
-		arg, err := s.resolve(f.In(i), trace)
+	for _, p := range params {
+		if err = ctx.Err(); err != nil {
+			return nil, newErrInvoke(s, function, err)
+		}
+
+		arg, err := s.resolveParam(ctx, p, trace)
 		if err != nil {
 			return nil, newErrInvoke(s, function, err)
 		}
-		args[i] = arg
+		args = append(args, arg)
+	}
+
+	if err = ctx.Err(); err != nil {
+		return nil, newErrInvoke(s, function, err)
+	}
+
+	out, err := s.call(function, args)
+	if err != nil {
+		return nil, newErrInvoke(s, function, err)
+	}
+
+	return out, nil
+}
+
+// call calls the given function, recovering any panic as [ErrPanic] if so configured.
+func (s *Scope) call(function reflect.Value, args []reflect.Value) (out []reflect.Value, err error) {
+	if s.recoverPanics {
+		defer func() {
+			if r := recover(); r != nil {
+				out, err = nil, newErrPanic(r)
+			}
+		}()
 	}
 
 	return function.Call(args), nil
 }
 
+// cachePanic records a panic as the error of a cached result, before continuing to panic.
+// It must be deferred directly.
+func cachePanic(result []reflect.Value) {
+	if r := recover(); r != nil {
+		result[1] = reflect.ValueOf(newErrPanic(r))
+		panic(r)
+	}
+}
+
+// aborted reports whether err was caused by the context being done, in which case the result
+// is specific to a single resolution, and must not be cached.
+func aborted(ctx context.Context, err error) bool {
+	return err != nil && ctx.Err() != nil && errors.Is(err, ctx.Err())
+}
+
 // Register adds new registrations to the scope.
 // Any of the builders in this package may be used to configure a registrable entry (see [Registrable]).
 // Registrations are validated at the time of registration,
 // and [ErrRegister] is returned for any errors encountered.
 func (s *Scope) Register(registrables ...Registrable) error {
+	return s.registerIn("", registrables)
+}
+
+func (s *Scope) registerIn(path string, registrables []Registrable) error {
 	errs := make([]error, len(registrables))
 
 	for i, r := range registrables {
-		if err := r.register(s); err != nil {
-			errs[i] = newErrRegister(s, r, err)
+		if m, ok := r.(*moduleBuilder); ok {
+			errs[i] = m.registerIn(s, path)
+		} else if err := r.register(s); err != nil {
+			errs[i] = newErrRegister(s, path, r, err)
 		}
 	}
 
@@ -119,13 +367,27 @@ func (s *Scope) MustRegister(registrables ...Registrable) {
 
 // Destroy finalizes the scope, and returns [ErrDestroy] for any errors encountered.
 // The scope must not be used after it has been destroyed.
-//   - All registered destroy functions are called.
+//   - All registered destroy functions are called, in the reverse order of creation.
 func (s *Scope) Destroy() error {
+	return s.DestroyContext(context.Background())
+}
+
+// DestroyContext is like [Scope.Destroy] but destroys within the given context.
+//   - The context is passed to any destroy function of the form func(context.Context, T)
+//     (see [IsValidDestroy]), limited by any [DestroyTimeout] of the scope.
+//   - A destroy function which outlasts its context is abandoned, and reported with the error of the context.
+//   - Once the context is done, destroy functions are no longer called,
+//     and each is reported with the error of the context.
+func (s *Scope) DestroyContext(ctx context.Context) error {
 	s.destroyersLock.RLock()
 	errs := make([]error, len(s.destroyers))
 
 	for i, d := range slices.Backward(s.destroyers) {
-		if err := d.Destroy(); err != nil {
+		err := ctx.Err()
+		if err == nil {
+			err = d.destroyIn(ctx, s)
+		}
+		if err != nil {
 			errs[i] = newErrDestroy(s, d, err)
 		}
 	}
@@ -141,12 +403,17 @@ func (s *Scope) MustDestroy() {
 	}
 }
 
+// MustDestroyContext is like [Scope.DestroyContext] but panics on error.
+func (s *Scope) MustDestroyContext(ctx context.Context) {
+	if err := s.DestroyContext(ctx); err != nil {
+		panic(err)
+	}
+}
+
 // ResolveIn resolves a value for the given type R within the given scope.
 // [ErrResolve] is returned if resolution fails.
 func ResolveIn[R any](s *Scope) (R, error) {
-	value, err := s.resolve(reflect.TypeFor[R](), nil)
-	iface, _ := value.Interface().(R)
-	return iface, err
+	return ResolveNamedInContext[R](context.Background(), s, "")
 }
 
 // MustResolveIn is like [ResolveIn] but panics on error.
@@ -158,10 +425,69 @@ func MustResolveIn[R any](s *Scope) R {
 	return iface
 }
 
+// ResolveNamedIn is like [ResolveIn] but resolves the registration of type R with the given name.
+// Registrations are named by their builders (e.g., with [FactoryBuilder.Named]).
+func ResolveNamedIn[R any](s *Scope, name string) (R, error) {
+	return ResolveNamedInContext[R](context.Background(), s, name)
+}
+
+// MustResolveNamedIn is like [ResolveNamedIn] but panics on error.
+func MustResolveNamedIn[R any](s *Scope, name string) R {
+	iface, err := ResolveNamedIn[R](s, name)
+	if err != nil {
+		panic(err)
+	}
+	return iface
+}
+
+// ResolveInContext is like [ResolveIn] but resolves within the given context.
+//   - The context is resolved for any dependency of type [context.Context] (e.g., the parameter of a create function),
+//     in place of any registration of that type.
+//   - Resolution is aborted with the error of the context once it is done,
+//     before the resolution of each further dependency.
+//   - Deferred dependencies (e.g., [Lazy]) resolve with the values of the context, but not its cancellation.
+//   - A value whose creation is aborted is not cached (e.g., by a [Singleton]),
+//     such that it is created again by the next resolution.
+func ResolveInContext[R any](ctx context.Context, s *Scope) (R, error) {
+	return ResolveNamedInContext[R](ctx, s, "")
+}
+
+// MustResolveInContext is like [ResolveInContext] but panics on error.
+func MustResolveInContext[R any](ctx context.Context, s *Scope) R {
+	iface, err := ResolveInContext[R](ctx, s)
+	if err != nil {
+		panic(err)
+	}
+	return iface
+}
+
+// ResolveNamedInContext is like [ResolveInContext] but resolves the registration of type R with the given name.
+// See [ResolveNamedIn] for details.
+func ResolveNamedInContext[R any](ctx context.Context, s *Scope, name string) (R, error) {
+	value, err := s.resolve(ctx, key{reflect.TypeFor[R](), name}, nil)
+	iface, _ := value.Interface().(R)
+	return iface, err
+}
+
 // InvokeIn calls the given function after resolving any input parameters
 // as dependencies with the given scope, and returns its result.
 // [ErrInvoke] is returned if invocation fails.
 func InvokeIn(s *Scope, function any) ([]any, error) {
+	return InvokeInContext(context.Background(), s, function)
+}
+
+// MustInvokeIn is like [InvokeIn] but panics on error.
+func MustInvokeIn(s *Scope, function any) []any {
+	ifaces, err := InvokeIn(s, function)
+	if err != nil {
+		panic(err)
+	}
+	return ifaces
+}
+
+// InvokeInContext is like [InvokeIn] but resolves within the given context.
+// See [ResolveInContext] for details.
+func InvokeInContext(ctx context.Context, s *Scope, function any) ([]any, error) {
 	f := reflect.ValueOf(function)
 
 	if !f.IsValid() {
@@ -174,7 +500,7 @@ func InvokeIn(s *Scope, function any) ([]any, error) {
 		return nil, newErrNil("function")
 	}
 
-	out, err := s.invoke(f, nil)
+	out, err := s.invoke(ctx, f, nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -187,9 +513,9 @@ func InvokeIn(s *Scope, function any) ([]any, error) {
 	return ifaces, nil
 }
 
-// MustInvokeIn is like [InvokeIn] but panics on error.
-func MustInvokeIn(s *Scope, function any) []any {
-	ifaces, err := InvokeIn(s, function)
+// MustInvokeInContext is like [InvokeInContext] but panics on error.
+func MustInvokeInContext(ctx context.Context, s *Scope, function any) []any {
+	ifaces, err := InvokeInContext(ctx, s, function)
 	if err != nil {
 		panic(err)
 	}
@@ -1,10 +1,13 @@
 package di_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"github.com/michaeljpetter/di"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestScope(t *testing.T) {
@@ -99,6 +102,102 @@ func TestScope(t *testing.T) {
 		assert.Panics(t, func() { s.MustDestroy() })
 	})
 
+	t.Run("DestroyContext", func(t *testing.T) {
+		type ctxKey struct{}
+		ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+
+		var destroyed []any
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1).Destroy(func(v int) { destroyed = append(destroyed, v) }),
+			di.Instance[string]("a").Destroy(func(ctx context.Context, v string) error {
+				destroyed = append(destroyed, v, ctx.Value(ctxKey{}))
+				return nil
+			}))
+
+		assert.NoError(t, s.DestroyContext(ctx))
+		assert.Equal(t, []any{"a", "value", 1}, destroyed)
+	})
+
+	t.Run("DestroyContextDone", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		var destroyed []any
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1).Destroy(func(v int) { destroyed = append(destroyed, v) }),
+			di.Instance[float64](2.5).Destroy(func(v float64) { destroyed = append(destroyed, v) }),
+			di.Instance[string]("a").Destroy(func(v string) { destroyed = append(destroyed, v); cancel() }))
+
+		err := s.DestroyContext(ctx)
+		assert.ErrorIs(t, err, di.ErrDestroy)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorContains(t, err, "destroy: test -> [int] 1")
+		assert.ErrorContains(t, err, "destroy: test -> [float64] 2.5")
+		assert.Equal(t, []any{"a"}, destroyed)
+
+		assert.Panics(t, func() { s.MustDestroyContext(ctx) })
+	})
+
+	t.Run("DestroyContextAbandoned", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		hung := make(chan struct{})
+		defer close(hung)
+
+		var destroyed []any
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1).Destroy(func(v int) { destroyed = append(destroyed, v) }),
+			di.Instance[string]("a").Destroy(func(string) { <-hung }))
+
+		err := s.DestroyContext(ctx)
+		assert.ErrorIs(t, err, di.ErrDestroy)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorContains(t, err, "destroy: test -> [string] a")
+		assert.ErrorContains(t, err, "destroy: test -> [int] 1")
+		assert.Empty(t, destroyed)
+	})
+
+	t.Run("DestroyTimeout", func(t *testing.T) {
+		hung := make(chan struct{})
+		defer close(hung)
+
+		var destroyed []any
+
+		s := di.NewScope("test", di.DestroyTimeout(10*time.Millisecond))
+		s.MustRegister(
+			di.Instance[int](1).Destroy(func(v int) { destroyed = append(destroyed, v) }),
+			di.Instance[float64](2.5).Destroy(func(ctx context.Context, v float64) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}),
+			di.Instance[string]("a").Destroy(func(string) { <-hung }))
+
+		err := s.Destroy()
+		assert.ErrorIs(t, err, di.ErrDestroy)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorContains(t, err, "destroy: test -> [string] a")
+		assert.ErrorContains(t, err, "destroy: test -> [float64] 2.5")
+		assert.NotContains(t, err.Error(), "[int] 1")
+		assert.Equal(t, []any{1}, destroyed)
+	})
+
+	t.Run("DestroyContextPanic", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1).Destroy(func(int) { panic("boom") }))
+
+		assert.PanicsWithValue(t, "boom", func() { s.DestroyContext(ctx) })
+	})
+
 	t.Run("ResolveIn", func(t *testing.T) {
 		s := di.NewScope("test")
 		s.MustRegister(
@@ -238,6 +337,216 @@ func TestScope(t *testing.T) {
 		assert.Panics(t, func() { di.MustInvokeIn(s, (func())(nil)) })
 	})
 
+	t.Run("ResolveInContext", func(t *testing.T) {
+		type ctxKey struct{}
+		ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[string](func(ctx context.Context) string { return ctx.Value(ctxKey{}).(string) }),
+			di.Instance[context.Context](context.TODO()))
+
+		value, err := di.ResolveInContext[string](ctx, s)
+		assert.Equal(t, "value", value)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ctx, di.MustResolveInContext[context.Context](ctx, s))
+		assert.Equal(t, context.Background(), di.MustResolveIn[context.Context](s))
+		assert.NoError(t, s.Validate())
+	})
+
+	t.Run("ResolveInContextOptional", func(t *testing.T) {
+		type params struct {
+			di.In
+			Ctx      context.Context `di:"optional"`
+			Optional di.Optional[context.Context]
+		}
+
+		ctx := context.WithValue(context.Background(), struct{}{}, "value")
+
+		s := di.NewScope("test")
+
+		out, err := di.InvokeInContext(ctx, s, func(p params) params { return p })
+		assert.NoError(t, err)
+
+		p := out[0].(params)
+		assert.Equal(t, ctx, p.Ctx)
+		assert.Equal(t, ctx, p.Optional.OrElse(nil))
+	})
+
+	t.Run("ResolveInContextCanceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		var created []string
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[int](func() int { created = append(created, "int"); cancel(); return 1 }),
+			di.Factory[float64](func() float64 { created = append(created, "float64"); return 2 }),
+			di.Factory[string](func(int, float64) string { created = append(created, "string"); return "" }))
+
+		value, err := di.ResolveInContext[string](ctx, s)
+		assert.Zero(t, value)
+		assert.ErrorIs(t, err, di.ErrResolve)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, []string{"int"}, created)
+
+		assert.Panics(t, func() { di.MustResolveInContext[string](ctx, s) })
+	})
+
+	t.Run("ResolveInContextCanceledNotCached", func(t *testing.T) {
+		type result struct {
+			di.Out
+			F float64
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		created := 0
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[int](func(ctx context.Context) (int, error) { cancel(); return 1, ctx.Err() }).Named("cancel"),
+			di.Singleton[string](func(int) string { created++; return "a" }).ArgNames("cancel"),
+			di.Scoped[uint](func(int) uint { created++; return 2 }).ArgNames("cancel"),
+			di.Provide(func(int) result { created++; return result{F: 3} }).ArgNames("cancel"))
+
+		_, err := di.ResolveInContext[string](ctx, s)
+		assert.ErrorIs(t, err, context.Canceled)
+		_, err = di.ResolveInContext[uint](ctx, s)
+		assert.ErrorIs(t, err, context.Canceled)
+		_, err = di.ResolveInContext[float64](ctx, s)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Zero(t, created)
+
+		assert.Equal(t, "a", di.MustResolveIn[string](s))
+		assert.Equal(t, uint(2), di.MustResolveIn[uint](s))
+		assert.Equal(t, 3.0, di.MustResolveIn[float64](s))
+		assert.Equal(t, 3, created)
+	})
+
+	t.Run("ResolveInContextDeferred", func(t *testing.T) {
+		type ctxKey struct{}
+		ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "value"))
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[string](func(ctx context.Context) (string, error) { return ctx.Value(ctxKey{}).(string), ctx.Err() }))
+
+		lazy := di.MustResolveInContext[di.Lazy[string]](ctx, s)
+		cancel()
+
+		value, err := lazy.Get()
+		assert.Equal(t, "value", value)
+		assert.NoError(t, err)
+	})
+
+	t.Run("ResolveNamedInContext", func(t *testing.T) {
+		type ctxKey struct{}
+		ctx := context.WithValue(context.Background(), ctxKey{}, "four")
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[int](func(ctx context.Context) int { return len(ctx.Value(ctxKey{}).(string)) }).Named("n"))
+
+		value, err := di.ResolveNamedInContext[int](ctx, s, "n")
+		assert.Equal(t, 4, value)
+		assert.NoError(t, err)
+	})
+
+	t.Run("InvokeInContext", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](3))
+
+		out, err := di.InvokeInContext(ctx, s, func(ctx context.Context, v int) (int, error) { return v, ctx.Err() })
+		assert.Equal(t, []any{3, nil}, out)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []any{3}, di.MustInvokeInContext(ctx, s, func(v int) int { return v }))
+	})
+
+	t.Run("InvokeInContextCanceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		s := di.NewScope("test")
+
+		out, err := di.InvokeInContext(ctx, s, func() { t.Error("should not call function") })
+		assert.Nil(t, out)
+		assert.ErrorIs(t, err, di.ErrInvoke)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		assert.Panics(t, func() { di.MustInvokeInContext(ctx, s, func() {}) })
+	})
+
+	t.Run("NewChild", func(t *testing.T) {
+		t.Run("String", func(t *testing.T) {
+			assert.Equal(t, "snappy", di.NewScope("test").NewChild("snappy").String())
+		})
+
+		t.Run("Fallback", func(t *testing.T) {
+			s := di.NewScope("test")
+			s.MustRegister(
+				di.Instance[int](3),
+				di.Instance[string]("parent"))
+
+			c := s.NewChild("child")
+			c.MustRegister(
+				di.Instance[string]("child"))
+
+			assert.Equal(t, 3, di.MustResolveIn[int](c))
+			assert.Equal(t, "child", di.MustResolveIn[string](c))
+			assert.Equal(t, "parent", di.MustResolveIn[string](s))
+		})
+
+		t.Run("NotRegistered", func(t *testing.T) {
+			c := di.NewScope("test").NewChild("child").NewChild("grandchild")
+
+			_, err := di.ResolveIn[int](c)
+			assert.ErrorIs(t, err, di.ErrResolve)
+			assert.ErrorIs(t, err, di.ErrNotRegistered)
+			assert.ErrorContains(t, err, "int (searched grandchild, child, test)")
+		})
+
+		t.Run("ResolvingScope", func(t *testing.T) {
+			s := di.NewScope("test")
+			s.MustRegister(
+				di.Instance[int](1),
+				di.Factory[string](func(i int) string { return fmt.Sprint(i) }),
+				di.Singleton[float64](func(i int) float64 { return float64(i) }))
+
+			c := s.NewChild("child")
+			c.MustRegister(
+				di.Instance[int](2))
+
+			assert.Equal(t, "2", di.MustResolveIn[string](c))
+			assert.Equal(t, 1., di.MustResolveIn[float64](c))
+		})
+
+		t.Run("Destroy", func(t *testing.T) {
+			var destroyed []any
+			destroy := func(v any) { destroyed = append(destroyed, v) }
+
+			s := di.NewScope("test")
+			s.MustRegister(
+				di.Factory[int](rotate(3, 7)).Destroy(destroy))
+
+			c := s.NewChild("child")
+
+			di.MustResolveIn[int](s)
+			di.MustResolveIn[int](c)
+
+			assert.NoError(t, c.Destroy())
+			assert.Equal(t, []any{7}, destroyed)
+
+			assert.NoError(t, s.Destroy())
+			assert.Equal(t, []any{7, 3}, destroyed)
+		})
+	})
+
 	t.Run("Cycle", func(t *testing.T) {
 		type (
 			A struct{}
@@ -0,0 +1,154 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+func scoped(s *Scope, b binding, provider reflect.Type, create reflect.Value, names []string, destroy reflect.Value) error {
+	r := b.t
+
+	value, err := validateCreate(r, create)
+	if err != nil {
+		return err
+	}
+
+	if err = validateNames("create", create, 0, names); err != nil {
+		return err
+	}
+
+	if err = validateDestroy(value, destroy); err != nil {
+		return err
+	}
+
+	id := new(byte)
+
+	_, err = s.registerProvider(&node{
+		binding: b,
+		kind:    "Scoped",
+		deps:    dependencies(create.Type(), 0, names),
+		provider: reflect.MakeFunc(
+			provider,
+			func(args []reflect.Value) []reflect.Value {
+				ctx := args[0].Interface().(context.Context)
+				resolver := args[1].Interface().(*Scope)
+				c := resolver.cached(id)
+
+				c.lock.Lock()
+				defer c.lock.Unlock()
+
+				if c.done {
+					return c.result
+				}
+
+				trace := args[2].Interface().(trace)
+
+				c.result = []reflect.Value{reflect.Zero(r), reflect.Zero(reflect.TypeFor[error]())}
+				c.done = true
+				defer cachePanic(c.result)
+
+				if out, err := resolver.invoke(ctx, create, names, trace); err != nil {
+					c.result[1] = reflect.ValueOf(err)
+				} else if 1 < len(out) && !out[1].IsNil() {
+					c.result[1] = out[1]
+				} else {
+					c.result[0] = out[0].Convert(r)
+					resolver.registerDestroyer(out[0], destroy, b.source)
+				}
+
+				if err, _ := c.result[1].Interface().(error); aborted(ctx, err) {
+					c.done = false
+				}
+
+				return c.result
+			},
+		),
+	})
+
+	return err
+}
+
+// ScopedBuilder provides configuration of a [Scoped].
+type ScopedBuilder interface {
+	Registrable
+	// Source returns the location at which this scoped was defined.
+	Source() Source
+	// Named configures the name under which this scoped is registered.
+	// See [ResolveNamedIn] for details.
+	Named(name string) ScopedBuilder
+	// Group configures this scoped as a member of the group for its resolved type R,
+	// rather than as the sole registration of R. Resolving []R returns the values of
+	// all members of the group, in registration order.
+	Group() ScopedBuilder
+	// MapKey configures this scoped as the entry with the given key in the map for its
+	// resolved type R, rather than as the sole registration of R. Resolving map[string]R
+	// returns the values of all entries of the map, with later registrations of a key
+	// taking precedence.
+	MapKey(key string) ScopedBuilder
+	// ArgNames configures the names of the registrations resolved for the
+	// parameters of the create function, in order. An empty name resolves
+	// the unnamed registration, as do any parameters beyond the given names.
+	// Parameter structs (see [In]) are configured by their tags, and must be given an empty name.
+	ArgNames(names ...string) ScopedBuilder
+	// Destroy configures a destroy function for the values created by this scoped.
+	// See IsValidDestroy for details.
+	Destroy(destroy any) ScopedBuilder
+}
+
+// Scoped defines a once-per-scope value creator (such as a "New" function).
+// The type parameter R defines the resolved type for created values.
+// See [IsValidCreate] for details.
+//
+// Scoped creates a new value the first time it is resolved within each scope, and returns
+// the same cached value every time thereafter within that scope.
+//   - Dependencies are resolved at the time of value creation.
+//   - Dependencies are resolved from the scope in which the scoped is being resolved.
+//   - Created values are destroyed with the scope in which they were resolved.
+func Scoped[R any](create any) ScopedBuilder {
+	return &scopedBuilder{
+		binding:  binding{key: key{t: reflect.TypeFor[R]()}, source: callerSource()},
+		provider: reflect.TypeFor[provider[R]](),
+		create:   reflect.ValueOf(create),
+	}
+}
+
+type scopedBuilder struct {
+	binding
+	provider        reflect.Type
+	names           []string
+	create, destroy reflect.Value
+}
+
+func (b *scopedBuilder) String() string {
+	return fmt.Sprintf("Scoped[%v] at %v", b.binding, b.source)
+}
+
+func (b *scopedBuilder) Named(name string) ScopedBuilder {
+	b.name = name
+	return b
+}
+
+func (b *scopedBuilder) Group() ScopedBuilder {
+	b.group, b.mapped = true, false
+	return b
+}
+
+func (b *scopedBuilder) MapKey(key string) ScopedBuilder {
+	b.group, b.mapped, b.mapKey = false, true, key
+	return b
+}
+
+func (b *scopedBuilder) ArgNames(names ...string) ScopedBuilder {
+	b.names = names
+	return b
+}
+
+func (b *scopedBuilder) Destroy(destroy any) ScopedBuilder {
+	b.destroy = reflect.ValueOf(destroy)
+	return b
+}
+
+func (b *scopedBuilder) register(s *Scope) error {
+	return scoped(s, b.binding, b.provider, b.create, b.names, b.destroy)
+}
@@ -0,0 +1,151 @@
+package di_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/michaeljpetter/di"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestScoped(t *testing.T) {
+	t.Run("Minimal", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Scoped[int](rotate(3.4, 5.6)))
+
+		for range 3 {
+			assert.Equal(t, 3, di.MustResolveIn[int](s))
+		}
+
+		s.MustDestroy()
+	})
+
+	t.Run("Full", func(t *testing.T) {
+		var destroyed []float64
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Scoped[int](rotate(3.4, 5.6, 7.8)).
+				Destroy(func(v float64) { destroyed = append(destroyed, v) }))
+
+		c1 := s.NewChild("child1")
+		c2 := s.NewChild("child2")
+
+		for range 3 {
+			assert.Equal(t, 3, di.MustResolveIn[int](c1))
+			assert.Equal(t, 5, di.MustResolveIn[int](c2))
+			assert.Equal(t, 7, di.MustResolveIn[int](s))
+		}
+
+		c2.MustDestroy()
+		assert.ElementsMatch(t, []float64{5.6}, destroyed)
+
+		c1.MustDestroy()
+		assert.ElementsMatch(t, []float64{5.6, 3.4}, destroyed)
+
+		s.MustDestroy()
+		assert.ElementsMatch(t, []float64{5.6, 3.4, 7.8}, destroyed)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		errs := rotate(errors.New("whoops"), errors.New("floops"))
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Scoped[int](func() (int, error) { return 77, errs() }))
+
+		c := s.NewChild("child")
+
+		for range 3 {
+			value, err := di.ResolveIn[int](s)
+			assert.Zero(t, value)
+			assert.ErrorContains(t, err, "whoops")
+		}
+
+		_, err := di.ResolveIn[int](c)
+		assert.ErrorContains(t, err, "floops")
+
+		s.MustDestroy()
+	})
+
+	t.Run("Dependent", func(t *testing.T) {
+		type double int
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](7),
+			di.Scoped[double](func(i int) int { return 2 * i }),
+		)
+
+		c := s.NewChild("child")
+		c.MustRegister(
+			di.Instance[int](11))
+
+		for range 3 {
+			assert.EqualValues(t, 14, di.MustResolveIn[double](s))
+			assert.EqualValues(t, 22, di.MustResolveIn[double](c))
+		}
+
+		s.MustDestroy()
+	})
+
+	t.Run("InvalidCreate", func(t *testing.T) {
+		s := di.NewScope("test")
+
+		err := s.Register(
+			di.Scoped[int](func() {}))
+
+		assert.ErrorIs(t, err, di.ErrInvalidFunc)
+
+		s.MustDestroy()
+	})
+
+	t.Run("InvalidDestroy", func(t *testing.T) {
+		s := di.NewScope("test")
+
+		err := s.Register(
+			di.Scoped[int](rotate(0)).
+				Destroy(func() {}))
+
+		assert.ErrorIs(t, err, di.ErrInvalidFunc)
+
+		s.MustDestroy()
+	})
+
+	t.Run("Named", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Scoped[int](rotate(1)),
+			di.Scoped[int](rotate(2)).Named("two"))
+
+		assert.Equal(t, 1, di.MustResolveIn[int](s))
+		assert.Equal(t, 2, di.MustResolveNamedIn[int](s, "two"))
+
+		s.MustDestroy()
+	})
+
+	t.Run("ArgNames", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1),
+			di.Instance[int](2).Named("two"),
+			di.Scoped[string](func(a, b int) string { return fmt.Sprint(a, b) }).
+				ArgNames("two"))
+
+		assert.Equal(t, "2 1", di.MustResolveIn[string](s))
+
+		s.MustDestroy()
+	})
+
+	t.Run("InvalidArgNames", func(t *testing.T) {
+		s := di.NewScope("test")
+
+		err := s.Register(
+			di.Scoped[int](rotate(0)).
+				ArgNames("a"))
+
+		assert.ErrorIs(t, err, di.ErrInvalidNames)
+
+		s.MustDestroy()
+	})
+}
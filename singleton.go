@@ -1,78 +1,188 @@
 package di
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"sync"
 )
 
-func singleton(s *Scope, r reflect.Type, provider reflect.Type, create reflect.Value, destroy reflect.Value) error {
+func singleton(s *Scope, b binding, provider reflect.Type, create reflect.Value, names []string, destroy, start, stop reflect.Value, retry, eager bool) error {
+	r := b.t
+
 	value, err := validateCreate(r, create)
 	if err != nil {
 		return err
 	}
 
+	if err = validateNames("create", create, 0, names); err != nil {
+		return err
+	}
+
 	if err = validateDestroy(value, destroy); err != nil {
 		return err
 	}
 
-	var once sync.Once
-	result := []reflect.Value{reflect.Zero(r), reflect.Zero(reflect.TypeFor[error]())}
+	if err = validateHook("start", value, start); err != nil {
+		return err
+	}
+
+	if err = validateHook("stop", value, stop); err != nil {
+		return err
+	}
+
+	var (
+		lock   sync.Mutex
+		done   bool
+		result []reflect.Value
+		n      *node
+	)
+
+	n = &node{
+		binding: b,
+		kind:    "Singleton",
+		deps:    dependencies(create.Type(), 0, names),
+		home:    s,
+		provider: reflect.MakeFunc(
+			provider,
+			func(args []reflect.Value) []reflect.Value {
+				lock.Lock()
+				defer lock.Unlock()
 
-	s.registerProvider(r, reflect.MakeFunc(
-		provider,
-		func(args []reflect.Value) []reflect.Value {
-			once.Do(func() {
-				trace := args[1].Interface().(trace)
+				if done {
+					return result
+				}
 
-				if out, err := s.invoke(create, trace); err != nil {
+				// each attempt creates a new result, as those of failed attempts may still be in use
+				result = []reflect.Value{reflect.Zero(r), reflect.Zero(reflect.TypeFor[error]())}
+				done = !retry
+				defer cachePanic(result)
+
+				ctx := args[0].Interface().(context.Context)
+				trace := args[2].Interface().(trace)
+
+				if out, err := s.invoke(ctx, create, names, trace); err != nil {
 					result[1] = reflect.ValueOf(err)
 				} else if 1 < len(out) && !out[1].IsNil() {
 					result[1] = out[1]
 				} else {
 					result[0] = out[0].Convert(r)
-					s.registerDestroyer(out[0], destroy)
+					s.registerDestroyer(out[0], destroy, b.source)
+					s.registerHook(n, out[0], start, stop, b.source)
+					done = true
+				}
+
+				if err, _ := result[1].Interface().(error); aborted(ctx, err) {
+					done = false
 				}
-			})
 
-			return result
-		},
-	))
+				return result
+			},
+		),
+	}
+
+	ok, err := s.registerProvider(n)
 
-	return nil
+	if ok && eager {
+		s.registerEager(n)
+	}
+
+	if ok && (start.IsValid() || stop.IsValid()) {
+		s.registerStarter(n)
+	}
+
+	return err
 }
 
 // SingletonBuilder provides configuration of a [Singleton].
 type SingletonBuilder interface {
 	Registrable
+	// Source returns the location at which this singleton was defined.
+	Source() Source
+	// Named configures the name under which this singleton is registered.
+	// See [ResolveNamedIn] for details.
+	Named(name string) SingletonBuilder
+	// Group configures this singleton as a member of the group for its resolved type R,
+	// rather than as the sole registration of R. Resolving []R returns the values of
+	// all members of the group, in registration order.
+	Group() SingletonBuilder
+	// MapKey configures this singleton as the entry with the given key in the map for its
+	// resolved type R, rather than as the sole registration of R. Resolving map[string]R
+	// returns the values of all entries of the map, with later registrations of a key
+	// taking precedence.
+	MapKey(key string) SingletonBuilder
+	// ArgNames configures the names of the registrations resolved for the
+	// parameters of the create function, in order. An empty name resolves
+	// the unnamed registration, as do any parameters beyond the given names.
+	// Parameter structs (see [In]) are configured by their tags, and must be given an empty name.
+	ArgNames(names ...string) SingletonBuilder
 	// Destroy configures a destroy function for the value created by this singleton.
 	// See IsValidDestroy for details.
 	Destroy(destroy any) SingletonBuilder
+	// RetryOnError configures this singleton to not cache a failed creation,
+	// such that the next resolution tries to create the value again.
+	// By default, the error of a failed creation is cached, and returned every time thereafter.
+	RetryOnError() SingletonBuilder
+	// Eager configures this singleton to be created by [Scope.Init],
+	// rather than the first time it is resolved.
+	Eager() SingletonBuilder
+	// OnStart configures a start function for the value created by this singleton,
+	// which is called by [Scope.Start]. See IsValidDestroy for the valid forms of the function.
+	OnStart(start any) SingletonBuilder
+	// OnStop configures a stop function for the value created by this singleton,
+	// which is called by [Scope.Stop]. See IsValidDestroy for the valid forms of the function.
+	OnStop(stop any) SingletonBuilder
 }
 
 // Singleton defines a one-time value creator (such as a "New" function).
 // The type parameter R defines the resolved type for the created value.
 // See [IsValidCreate] for details.
 //
-// Singleton creates a new value the first time it is resolved, and returns
-// the same cached value every time thereafter.
+// Singleton creates a new value the first time it is resolved (or when initialized, if eager),
+// and returns the same cached value every time thereafter.
+//   - A failed creation is cached in the same way, unless configured by [SingletonBuilder.RetryOnError],
+//     or aborted by the context of the resolution (see [ResolveInContext]).
 //   - Dependencies are resolved at the time of value creation.
 //   - Dependencies are resolved from the scope in which the singleton was registered.
 func Singleton[R any](create any) SingletonBuilder {
 	return &singletonBuilder{
-		r:        reflect.TypeFor[R](),
+		binding:  binding{key: key{t: reflect.TypeFor[R]()}, source: callerSource()},
 		provider: reflect.TypeFor[provider[R]](),
 		create:   reflect.ValueOf(create),
 	}
 }
 
 type singletonBuilder struct {
-	r, provider     reflect.Type
+	binding
+	provider        reflect.Type
+	names           []string
 	create, destroy reflect.Value
+	start, stop     reflect.Value
+	retry, eager    bool
 }
 
 func (b *singletonBuilder) String() string {
-	return fmt.Sprintf("Singleton[%s]", typeName(b.r))
+	return fmt.Sprintf("Singleton[%v] at %v", b.binding, b.source)
+}
+
+func (b *singletonBuilder) Named(name string) SingletonBuilder {
+	b.name = name
+	return b
+}
+
+func (b *singletonBuilder) Group() SingletonBuilder {
+	b.group, b.mapped = true, false
+	return b
+}
+
+func (b *singletonBuilder) MapKey(key string) SingletonBuilder {
+	b.group, b.mapped, b.mapKey = false, true, key
+	return b
+}
+
+func (b *singletonBuilder) ArgNames(names ...string) SingletonBuilder {
+	b.names = names
+	return b
 }
 
 func (b *singletonBuilder) Destroy(destroy any) SingletonBuilder {
@@ -80,6 +190,26 @@ func (b *singletonBuilder) Destroy(destroy any) SingletonBuilder {
 	return b
 }
 
+func (b *singletonBuilder) RetryOnError() SingletonBuilder {
+	b.retry = true
+	return b
+}
+
+func (b *singletonBuilder) Eager() SingletonBuilder {
+	b.eager = true
+	return b
+}
+
+func (b *singletonBuilder) OnStart(start any) SingletonBuilder {
+	b.start = reflect.ValueOf(start)
+	return b
+}
+
+func (b *singletonBuilder) OnStop(stop any) SingletonBuilder {
+	b.stop = reflect.ValueOf(stop)
+	return b
+}
+
 func (b *singletonBuilder) register(s *Scope) error {
-	return singleton(s, b.r, b.provider, b.create, b.destroy)
+	return singleton(s, b.binding, b.provider, b.create, b.names, b.destroy, b.start, b.stop, b.retry, b.eager)
 }
@@ -2,8 +2,11 @@ package di_test
 
 import (
 	"errors"
+	"fmt"
 	"github.com/michaeljpetter/di"
 	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
 	"testing"
 )
 
@@ -90,4 +93,105 @@ func TestSingleton(t *testing.T) {
 
 		s.MustDestroy()
 	})
+
+	t.Run("Named", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Singleton[int](rotate(1)),
+			di.Singleton[int](rotate(2)).Named("two"))
+
+		assert.Equal(t, 1, di.MustResolveIn[int](s))
+		assert.Equal(t, 2, di.MustResolveNamedIn[int](s, "two"))
+
+		s.MustDestroy()
+	})
+
+	t.Run("ArgNames", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[int](1),
+			di.Instance[int](2).Named("two"),
+			di.Singleton[string](func(a, b int) string { return fmt.Sprint(a, b) }).
+				ArgNames("two"))
+
+		assert.Equal(t, "2 1", di.MustResolveIn[string](s))
+
+		s.MustDestroy()
+	})
+
+	t.Run("InvalidArgNames", func(t *testing.T) {
+		s := di.NewScope("test")
+
+		err := s.Register(
+			di.Singleton[int](rotate(0)).
+				ArgNames("a"))
+
+		assert.ErrorIs(t, err, di.ErrInvalidNames)
+
+		s.MustDestroy()
+	})
+
+	t.Run("RetryOnError", func(t *testing.T) {
+		var destroyed []int
+		errs := rotate(errors.New("whoops"), errors.New("floops"), nil)
+		values := rotate(1, 2, 3)
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Singleton[int](func() (int, error) { return values(), errs() }).
+				Destroy(func(v int) { destroyed = append(destroyed, v) }).
+				RetryOnError())
+
+		_, err := di.ResolveIn[int](s)
+		assert.ErrorContains(t, err, "whoops")
+
+		_, err = di.ResolveIn[int](s)
+		assert.ErrorContains(t, err, "floops")
+
+		for range 3 {
+			assert.Equal(t, 3, di.MustResolveIn[int](s))
+		}
+
+		s.MustDestroy()
+
+		assert.Equal(t, []int{3}, destroyed)
+	})
+
+	t.Run("RetryOnErrorConcurrent", func(t *testing.T) {
+		var created atomic.Int32
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Singleton[int](func() (int, error) {
+				if n := created.Add(1); n <= 5 {
+					return 0, fmt.Errorf("attempt %d", n)
+				}
+				return 42, nil
+			}).RetryOnError())
+
+		var wg sync.WaitGroup
+		results := make([]int, 50)
+
+		for i := range results {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					if value, err := di.ResolveIn[int](s); err == nil {
+						results[i] = value
+						return
+					}
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		assert.Equal(t, int32(6), created.Load())
+		for _, value := range results {
+			assert.Equal(t, 42, value)
+		}
+
+		s.MustDestroy()
+	})
 }
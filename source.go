@@ -0,0 +1,44 @@
+package di
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// Source is the location in source code at which a registration was defined.
+type Source struct {
+	// File is the full path of the source file.
+	File string
+	// Line is the line number within the source file.
+	Line int
+}
+
+// String returns the location as "dir/file.go:line",
+// where dir is the final element of the directory containing the file.
+func (s Source) String() string {
+	if s.File == "" {
+		return "unknown"
+	}
+	return fmt.Sprintf("%s:%d", path.Join(path.Base(path.Dir(s.File)), path.Base(s.File)), s.Line)
+}
+
+var pkgPath = reflect.TypeFor[Source]().PkgPath()
+
+// callerSource returns the location of the nearest caller outside of this package (or its subpackages).
+func callerSource() Source {
+	pc := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pc[:runtime.Callers(2, pc)])
+
+	for {
+		f, more := frames.Next()
+		if !strings.HasPrefix(f.Function, pkgPath+".") && !strings.HasPrefix(f.Function, pkgPath+"/") {
+			return Source{f.File, f.Line}
+		}
+		if !more {
+			return Source{}
+		}
+	}
+}
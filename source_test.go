@@ -0,0 +1,83 @@
+package di_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/michaeljpetter/di"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func here() di.Source {
+	_, file, line, _ := runtime.Caller(1)
+	return di.Source{File: file, Line: line}
+}
+
+func TestSource(t *testing.T) {
+	type A struct{}
+
+	at := func(s di.Source, offset int) string {
+		s.Line += offset
+		return s.String()
+	}
+
+	t.Run("String", func(t *testing.T) {
+		assert.Equal(t, "unknown", di.Source{}.String())
+		assert.Equal(t, "pkg/file.go:12", di.Source{File: "/src/pkg/file.go", Line: 12}.String())
+
+		s := here()
+		assert.Equal(t, fmt.Sprintf("%s/source_test.go:%d", filepath.Base(filepath.Dir(s.File)), s.Line), s.String())
+	})
+
+	t.Run("Builders", func(t *testing.T) {
+		s := here()
+		builders := []interface{ Source() di.Source }{
+			di.Instance[A](A{}),
+			di.Factory[A](func() A { return A{} }),
+			di.Singleton[A](func() A { return A{} }),
+			di.Scoped[A](func() A { return A{} }),
+			di.Alias[A, A](),
+			di.Provide(func() struct{ di.Out } { return struct{ di.Out }{} }),
+			di.Decorate[A](func(a A) A { return a }),
+		}
+
+		for i, b := range builders {
+			assert.Equal(t, s.File, b.Source().File)
+			assert.Equal(t, s.Line+2+i, b.Source().Line)
+			assert.Contains(t, fmt.Sprint(b), " at "+at(s, 2+i))
+		}
+	})
+
+	t.Run("Register", func(t *testing.T) {
+		s := here()
+		err := di.NewScope("test").Register(
+			di.Factory[A](nil))
+
+		assert.ErrorIs(t, err, di.ErrRegister)
+		assert.ErrorContains(t, err, "register: test <- Factory[di_test.A] at "+at(s, 2))
+	})
+
+	t.Run("Resolve", func(t *testing.T) {
+		s := here()
+		scope := di.NewScope("test")
+		scope.MustRegister(
+			di.Factory[A](func() (A, error) { return A{}, errors.New("failed") }))
+
+		_, err := di.ResolveIn[A](scope)
+		assert.ErrorIs(t, err, di.ErrResolve)
+		assert.ErrorContains(t, err, "resolve: test -> di_test.A at "+at(s, 3))
+	})
+
+	t.Run("Destroy", func(t *testing.T) {
+		s := here()
+		scope := di.NewScope("test")
+		scope.MustRegister(
+			di.Instance[int](1).Destroy(func(int) error { return errors.New("failed") }))
+
+		err := scope.Destroy()
+		assert.ErrorIs(t, err, di.ErrDestroy)
+		assert.ErrorContains(t, err, "destroy: test -> [int] 1 at "+at(s, 3))
+	})
+}
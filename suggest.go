@@ -0,0 +1,53 @@
+package di
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+)
+
+// suggestions returns the keys registered in any of the given scopes which
+// are near-misses for k, such as the pointer form of a requested value type.
+func suggestions(k key, scopes []*Scope) []Key {
+	var near []Key
+
+	for _, s := range scopes {
+		s.providersLock.RLock()
+		for c := range s.providers {
+			if c.name == k.name && c.t != k.t && isNearMiss(k.t, c.t) && !slices.Contains(near, Key{c.t, c.name}) {
+				near = append(near, Key{c.t, c.name})
+			}
+		}
+		s.providersLock.RUnlock()
+	}
+
+	slices.SortFunc(near, func(a, b Key) int {
+		return cmp.Compare(a.String(), b.String())
+	})
+
+	return near
+}
+
+func isNearMiss(requested, registered reflect.Type) bool {
+	switch {
+	case requested == reflect.PointerTo(registered) || registered == reflect.PointerTo(requested):
+		return true
+	case registered.Kind() == reflect.Interface && requested.Kind() != reflect.Interface:
+		return 0 < registered.NumMethod() && requested.Implements(registered)
+	case requested.Kind() == reflect.Interface && registered.Kind() != reflect.Interface:
+		return 0 < requested.NumMethod() && registered.Implements(requested)
+	}
+
+	requested, registered = elem(requested), elem(registered)
+
+	return requested.Name() != "" &&
+		requested.Name() == registered.Name() &&
+		requested.PkgPath() != registered.PkgPath()
+}
+
+func elem(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,133 @@
+package di_test
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/michaeljpetter/di"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type suggestStringer struct{}
+
+func (suggestStringer) String() string { return "" }
+
+func TestSuggestions(t *testing.T) {
+	type (
+		A      struct{}
+		Buffer struct{}
+	)
+
+	suggest := func(t *testing.T, s *di.Scope, resolve func(*di.Scope) error) []di.Key {
+		err := resolve(s)
+		assert.ErrorIs(t, err, di.ErrNotRegistered)
+
+		var e *di.NotRegisteredError
+		if !assert.ErrorAs(t, err, &e) {
+			return nil
+		}
+		return e.Suggestions
+	}
+
+	resolve := func(t *testing.T, s *di.Scope, r reflect.Type) []di.Key {
+		return suggest(t, s, func(s *di.Scope) error {
+			_, err := di.InvokeIn(s, reflect.MakeFunc(reflect.FuncOf([]reflect.Type{r}, nil, false), nil).Interface())
+			return err
+		})
+	}
+
+	t.Run("Pointer", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[*A](&A{}),
+			di.Instance[int](1))
+
+		assert.Equal(t, []di.Key{{Type: reflect.TypeFor[*A]()}}, resolve(t, s, reflect.TypeFor[A]()))
+
+		_, err := di.ResolveIn[A](s)
+		assert.ErrorContains(t, err, "not registered: di_test.A (searched test; did you mean *di_test.A?)")
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[A](A{}))
+
+		assert.Equal(t, []di.Key{{Type: reflect.TypeFor[A]()}}, resolve(t, s, reflect.TypeFor[*A]()))
+	})
+
+	t.Run("Interface", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[fmt.Stringer](suggestStringer{}),
+			di.Instance[any](1))
+
+		assert.Equal(t, []di.Key{{Type: reflect.TypeFor[fmt.Stringer]()}}, resolve(t, s, reflect.TypeFor[suggestStringer]()))
+	})
+
+	t.Run("Implementation", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[suggestStringer](suggestStringer{}),
+			di.Instance[*bytes.Buffer](new(bytes.Buffer)),
+			di.Instance[A](A{}))
+
+		assert.Equal(t, []di.Key{
+			{Type: reflect.TypeFor[*bytes.Buffer]()},
+			{Type: reflect.TypeFor[suggestStringer]()},
+		}, resolve(t, s, reflect.TypeFor[fmt.Stringer]()))
+
+		_, err := di.ResolveIn[fmt.Stringer](s)
+		assert.ErrorContains(t, err, "did you mean *bytes.Buffer or di_test.suggestStringer?")
+	})
+
+	t.Run("Package", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[bytes.Buffer](bytes.Buffer{}))
+
+		assert.Equal(t, []di.Key{{Type: reflect.TypeFor[bytes.Buffer]()}}, resolve(t, s, reflect.TypeFor[Buffer]()))
+		assert.Equal(t, []di.Key{{Type: reflect.TypeFor[bytes.Buffer]()}}, resolve(t, s, reflect.TypeFor[*Buffer]()))
+	})
+
+	t.Run("Name", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[*A](&A{}).Named("a"))
+
+		assert.Empty(t, resolve(t, s, reflect.TypeFor[A]()))
+
+		_, err := di.ResolveNamedIn[A](s, "a")
+		assert.ErrorContains(t, err, `did you mean *di_test.A "a"?`)
+	})
+
+	t.Run("Parent", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[*A](&A{}))
+
+		c := s.NewChild("child")
+
+		_, err := di.ResolveIn[A](c)
+		assert.ErrorContains(t, err, "(searched child, test; did you mean *di_test.A?)")
+	})
+
+	t.Run("Decorate", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[*A](&A{}))
+
+		err := s.Register(di.Decorate[A](func(a A) A { return a }))
+		assert.ErrorContains(t, err, "did you mean *di_test.A?")
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Instance[*A](&A{}),
+			di.Factory[int](func(A) int { return 0 }))
+
+		assert.ErrorContains(t, s.Validate(), "did you mean *di_test.A?")
+	})
+}
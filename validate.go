@@ -0,0 +1,121 @@
+package di
+
+import (
+	"cmp"
+	"errors"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+type visit struct {
+	s *Scope
+	k key
+}
+
+type validation struct {
+	root    *Scope
+	visited map[visit]bool
+	errs    []error
+}
+
+func (v *validation) visitKey(s *Scope, k key, n *node, trace trace) {
+	at := visit{s, k}
+
+	if done, ok := v.visited[at]; ok {
+		if !done {
+			cycle := slices.Index(trace, k)
+			v.errs = append(v.errs, newErrValidate(v.root, trace[:cycle+1], newErrCycle(append(slices.Clone(trace[cycle:]), k))))
+		}
+		return
+	}
+
+	v.visited[at] = false
+	v.visitNode(s, n, append(trace, k))
+	v.visited[at] = true
+}
+
+func (v *validation) visitNode(s *Scope, n *node, trace trace) {
+	from := s
+	if n.home != nil {
+		from = n.home
+	}
+
+	for _, d := range n.deps {
+		v.visitDependency(from, d, trace)
+	}
+
+	if n.decorated != nil {
+		v.visitNode(s, n.decorated, trace)
+	}
+
+	if n.owner != nil {
+		for _, m := range n.owner.collectionMembers(n.key) {
+			v.visitNode(s, m, trace)
+		}
+	}
+}
+
+func (v *validation) visitDependency(s *Scope, d dependency, trace trace) {
+	if d.key == contextKey {
+		return
+	}
+
+	if d.t.Implements(reflect.TypeFor[resolvable]()) {
+		d = reflect.Zero(d.t).Interface().(resolvable).dependency(d.key)
+	}
+
+	n, searched := s.lookup(d.key)
+
+	if n == nil {
+		if !d.optional && !d.group {
+			v.errs = append(v.errs, newErrValidate(v.root, trace, newErrNotRegistered(d.key, searched)))
+		}
+		return
+	}
+
+	if !d.deferred {
+		v.visitKey(s, d.key, n, trace)
+	}
+}
+
+func (s *Scope) keys() []key {
+	keys := make(map[key]bool)
+
+	for scope := s; scope != nil; scope = scope.parent {
+		scope.providersLock.RLock()
+		for k := range scope.providers {
+			keys[k] = true
+		}
+		scope.providersLock.RUnlock()
+	}
+
+	return slices.SortedFunc(maps.Keys(keys), func(a, b key) int {
+		return cmp.Compare(a.String(), b.String())
+	})
+}
+
+// Validate checks all registrations which may be resolved within the scope,
+// without creating any values, and returns [ErrValidate] for every problem found:
+//   - [ErrNotRegistered] for each missing dependency.
+//   - [ErrCycle] for each cycle among dependencies.
+//
+// Dependencies which need not be registered (e.g., [Optional]) are not reported,
+// nor are cycles through deferred dependencies (e.g., [Lazy]).
+func (s *Scope) Validate() error {
+	v := validation{root: s, visited: make(map[visit]bool)}
+
+	for _, k := range s.keys() {
+		n, _ := s.lookup(k)
+		v.visitKey(s, k, n, nil)
+	}
+
+	return errors.Join(v.errs...)
+}
+
+// MustValidate is like [Scope.Validate] but panics on error.
+func (s *Scope) MustValidate() {
+	if err := s.Validate(); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,115 @@
+package di_test
+
+import (
+	"github.com/michaeljpetter/di"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	type (
+		A struct{}
+		B struct{}
+		C struct{}
+		D struct{}
+	)
+
+	noCall := func() { t.Error("should not call create function") }
+
+	t.Run("Valid", func(t *testing.T) {
+		type in struct {
+			di.In
+			C C          `di:"name=c"`
+			D D          `di:"optional"`
+			G []float64  `di:"group"`
+			L di.Lazy[A] `di:""`
+		}
+
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[A](func(B, di.Optional[D]) A { noCall(); return A{} }),
+			di.Singleton[B](func(in) B { noCall(); return B{} }),
+			di.Scoped[C](func(int) C { noCall(); return C{} }).Named("c"),
+			di.Instance[int](1),
+			di.Instance[int](2).Group(),
+			di.Alias[float64, int]().MapKey("a"),
+			di.Decorate[A](func(a A, _ []int) A { noCall(); return a }))
+
+		assert.NoError(t, s.Validate())
+		assert.NotPanics(t, func() { s.MustValidate() })
+	})
+
+	t.Run("NotRegistered", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[A](func(B, C) A { noCall(); return A{} }),
+			di.Singleton[B](func(D) B { noCall(); return B{} }),
+			di.Alias[int, float64]().Group(),
+			di.Decorate[A](func(a A, _ string) A { noCall(); return a }))
+
+		c := s.NewChild("child")
+		c.MustRegister(
+			di.Instance[C](C{}))
+
+		err := s.Validate()
+		assert.ErrorIs(t, err, di.ErrValidate)
+		assert.ErrorIs(t, err, di.ErrNotRegistered)
+		assert.ErrorContains(t, err, "validate: test -> di_test.A\n └> di: not registered: di_test.C (searched test)")
+		assert.ErrorContains(t, err, "validate: test -> di_test.A -> di_test.B\n └> di: not registered: di_test.D (searched test)")
+		assert.ErrorContains(t, err, "validate: test -> di_test.A\n └> di: not registered: string (searched test)")
+		assert.ErrorContains(t, err, "validate: test -> []int\n └> di: not registered: float64 (searched test)")
+		assert.Equal(t, 4, strings.Count(err.Error(), "validate:"))
+
+		err = c.Validate()
+		assert.ErrorIs(t, err, di.ErrValidate)
+		assert.NotContains(t, err.Error(), "di_test.C (searched")
+		assert.Equal(t, 3, strings.Count(err.Error(), "validate:"))
+
+		assert.Panics(t, func() { s.MustValidate() })
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[A](func(B) A { noCall(); return A{} }),
+			di.Singleton[B](func(C) B { noCall(); return B{} }),
+			di.Factory[C](func(A) C { noCall(); return C{} }),
+			di.Alias[D, D]())
+
+		err := s.Validate()
+		assert.ErrorIs(t, err, di.ErrValidate)
+		assert.ErrorIs(t, err, di.ErrCycle)
+		assert.ErrorContains(t, err, "cycle detected: di_test.A -> di_test.B -> di_test.C -> di_test.A")
+		assert.ErrorContains(t, err, "cycle detected: di_test.D -> di_test.D")
+		assert.Equal(t, 2, strings.Count(err.Error(), "validate:"))
+	})
+
+	t.Run("DeferredCycle", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Factory[A](func(di.Lazy[B]) A { noCall(); return A{} }),
+			di.Factory[B](func(di.Provider[A]) B { noCall(); return B{} }),
+			di.Factory[C](func(di.Lazy[D]) C { noCall(); return C{} }))
+
+		err := s.Validate()
+		assert.ErrorIs(t, err, di.ErrNotRegistered)
+		assert.NotErrorIs(t, err, di.ErrCycle)
+		assert.ErrorContains(t, err, "validate: test -> di_test.C\n └> di: not registered: di_test.D")
+	})
+
+	t.Run("RegisteringScope", func(t *testing.T) {
+		s := di.NewScope("test")
+		s.MustRegister(
+			di.Singleton[A](func(C) A { noCall(); return A{} }),
+			di.Factory[B](func(C) B { noCall(); return B{} }))
+
+		c := s.NewChild("child")
+		c.MustRegister(
+			di.Instance[C](C{}))
+
+		err := c.Validate()
+		assert.ErrorContains(t, err, "validate: child -> di_test.A\n └> di: not registered: di_test.C (searched test)")
+		assert.NotContains(t, err.Error(), "di_test.B")
+	})
+}